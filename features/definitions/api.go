@@ -2,6 +2,7 @@ package definitions
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/cucumber/godog"
 
@@ -23,6 +24,14 @@ func InstallAPI(s *godog.ScenarioContext, client *api.Client) {
 	s.Step(`^(?:I )?do not follow redirect$`, client.DisableRedirect)
 	// Enable follow redirection
 	s.Step(`^(?:I )?follow redirect$`, client.FollowRedirect)
+	// Force protocol used to emit requests. Protocol is reset with http client
+	s.Step(`^(?:I )?use (HTTP/1\.1|HTTP/2|h2c|default) protocol$`, client.UseProtocol)
+	// Trust PEM certificate authorities from file or skip certificate verification. Reset with http client
+	s.Step(`^(?:I )?trust certificate (.+)$`, client.TrustCertificate)
+	s.Step(`^(?:I )?skip tls verification$`, client.SkipTLSVerification)
+	// Emit every request on an Unix domain socket. Socket is reset with http client
+	s.Step(`^(?:I )?use unix socket ([^ ]+)$`, client.UseUnixSocket)
+	s.Step(`^(?:I )?do not use unix socket$`, client.DisableUnixSocket)
 	// Enable cookies
 	s.Step(`(?:I )?enabl(?:e|ing) cookie$`, client.EnableCookie)
 	// Disable cookies
@@ -33,8 +42,22 @@ func InstallAPI(s *godog.ScenarioContext, client *api.Client) {
 	s.Step(`(?:I )?disable auto reset for request$`, client.DisableAutoResetRequest)
 	s.Step(`(?:I )?enable auto reset for request$`, client.EnableAutoResetRequest)
 
+	// SIGNING ------------------
+	// Sign following requests using an HMAC configured by a `key | value` table
+	s.Step(`^(?:I )?sign requests with hmac:$`, client.SignWithHMAC)
+	// Sign following requests using AWS Signature V4 configured by a `key | value` table
+	s.Step(`^(?:I )?sign requests with aws v4:$`, client.SignWithAWSV4)
+	// Stop signing requests
+	s.Step(`^(?:I )?do not sign requests$`, client.DisableSigning)
+
 	// REQUEST ---------------------
-	s.Step(`(?:I )?execut(?:e|ing) request$`, client.ExecuteRequest)
+	s.Step(`(?:I )?execut(?:e|ing) request(?: with a timeout of (\d+(?:\.\d+)?) seconds?)?$`, func(timeout string) error {
+		if err := setTimeout(client, timeout); err != nil {
+			return err
+		}
+
+		return client.ExecuteRequest()
+	})
 	// Set up request
 	s.Step(
 		`^(?:I )?want(?:ing)? to (GET|PUT|POST|DELETE) (.*)$`,
@@ -44,23 +67,74 @@ func InstallAPI(s *godog.ScenarioContext, client *api.Client) {
 		},
 	)
 	s.Step(
-		`^(?:I )?(GET|PUT|POST|DELETE) (.*)$`,
-		func(method, endpoint string) error {
+		`^(?:I )?(GET|PUT|POST|DELETE) (.*?)(?: with a timeout of (\d+(?:\.\d+)?) seconds?)?$`,
+		func(method, endpoint, timeout string) error {
 			client.SetEndpoint(endpoint)
 			if err := client.SetMethod(method); err != nil {
 				return err
 			}
 
+			if err := setTimeout(client, timeout); err != nil {
+				return err
+			}
+
 			// InitRequest mandatory cause we wish to ensure the call is on the correct value
 			return client.ExecuteRequest()
 		},
 	)
 
+	// TIMEOUT ---------------------
+	// Default timeout applies to every request without its own timeout. It is kept on client reset
+	s.Step(`^(?:I )?set default request timeout to (\d+(?:\.\d+)?) seconds?$`, client.SetDefaultTimeoutSeconds)
+	// Set current request timeout
+	s.Step(`^(?:I )?set request timeout to (\d+(?:\.\d+)?) seconds?$`, client.SetRequestTimeout)
+	// Execute current request and check it is not answered before timeout
+	s.Step(`^request should time out within (\d+(?:\.\d+)?) seconds?$`, client.RequestShouldTimeOutWithin)
+
+	// BURST ---------------------
+	// Send currently prepared request N times using C concurrent workers
+	s.Step(`^(?:I )?send the request (\d+) times with (\d+) workers?$`, client.SendBurst)
+	// Check burst status distribution
+	s.Step(`^burst should have no errors$`, client.BurstShouldHaveNoErrors)
+	s.Step(`^every burst response should have status (\d+)$`, client.EveryBurstStatusShouldBe)
+	s.Step(`^(\d+) burst responses? should have status (\d+)$`, client.BurstStatusCountShouldBe)
+	s.Step(
+		`^at least (\d+)(%)? of burst responses should have status (\d+)$`,
+		func(minimum int, inPercent string, status int) error {
+			return client.BurstStatusShouldBeAtLeast(minimum, inPercent != "", status)
+		},
+	)
+	s.Step(
+		`^no burst response should have status (\d+)$`,
+		func(status int) error {
+			return client.BurstStatusCountShouldBe(0, status)
+		},
+	)
+	// Check burst latency percentiles. Duration uses go format (150ms, 2s)
+	s.Step(`^burst (p\d+(?:\.\d+)?) latency should be under (\d+(?:\.\d+)?(?:ms|s|us|µs))$`, client.BurstLatencyShouldBeUnder)
+
 	// BODY ---------------------
 	// form && json are mutually exclusive. If both are defined, only JSON will be used
 	s.Step(`(?:I )?set(?:ing)? request form body:$`, client.SetFormBody)
 	s.Step(`(?:I )?set(?:ing)? request json body:$`, client.SetJSONBody)
+	// Build json body from a `field | value` table using dot paths and type hints
+	s.Step(`^(?:I )?set(?:ing)? request json body from table:$`, client.SetJSONBodyFromTable)
+	// Load json body template from file, optionally overriding `field | value` rows
+	s.Step(`^(?:I )?set(?:ing)? request json body from ([^ ]+\.json)$`, func(path string) error {
+		return client.SetJSONBodyFromFile(path, nil)
+	})
+	s.Step(`^(?:I )?set(?:ing)? request json body from ([^ ]+\.json) with:$`, client.SetJSONBodyFromFile)
+	// Load json body template from file and apply a JSON Merge Patch or JSON Patch DocString
+	s.Step(`^(?:I )?set(?:ing)? request json body from ([^ ]+\.json) with (merge|json) patch:$`, client.SetPatchedJSONBodyFromFile)
 	s.Step(`(?:I )?clear(?:ing)? request body$`, client.ClearBody)
+	// Compress request body and set Content-Encoding header
+	s.Step(`^(?:I )?compress request body (?:with|using) (gzip|deflate|br|brotli|zstd)$`, client.CompressBody)
+	// Encode json DocString to a binary format
+	s.Step(`^(?:I )?set(?:ing)? request (msgpack|cbor) body:$`, client.SetEncodedBody)
+	// Encode json DocString as protobuf message using a descriptor set file
+	s.Step(`^(?:I )?set(?:ing)? request protobuf body as ([^ ]+) using descriptor (.+):$`, client.SetProtobufBody)
+	// Decode response body as protobuf message. Msgpack and cbor are decoded from Content-Type
+	s.Step(`^(?:I )?decode response protobuf as ([^ ]+) using descriptor (.+)$`, client.DecodeResponseAsProtobuf)
 
 	// QUERY PARAMS -------------
 	s.Step(`(?:I )?set(?:ing)? request query$`, client.SetQueryParams)
@@ -69,12 +143,22 @@ func InstallAPI(s *godog.ScenarioContext, client *api.Client) {
 	// COOKIES ------------------
 	// Simulate browser/app side cookie
 	s.Step(`(?:I )?set(?:ing)? cookie ([a-zA-Z0-9-]+) to (.+)(?: with options:)?$`, client.AddCookie)
+	// Save client cookies as a named session kept across scenarios
+	s.Step(`^(?:I )?save cookie session as ([^ ]+)$`, client.SaveCookieSession)
+	// Replace client cookies by a saved session
+	s.Step(`^(?:I )?restore cookie session ([^ ]+)$`, client.RestoreCookieSession)
+	// Delete a saved session
+	s.Step(`^(?:I )?forget cookie session ([^ ]+)$`, client.ForgetCookieSession)
 
 	// Picking
 	// Pick response header value
 	s.Step(`^(?:I )?pick response header ([a-zA-Z1-9_-]+) as ([a-zA-Z0-9]+)$`, client.PickResponseHeader)
+	// Pick every response header value as an array
+	s.Step(`^(?:I )?pick response header ([a-zA-Z1-9_-]+) values as ([a-zA-Z0-9]+)$`, client.PickResponseHeaderValues)
 	// Pick key from URL Arg
 	s.Step(`^(?:I )?pick key ([a-zA-Z1-9_-]+) from url ([^ ]+) as ([a-zA-Z0-9]+)$`, client.PickArgumentFromURLArg)
+	// Pick key from query of URL which answered response, after redirections
+	s.Step(`^(?:I )?pick key ([a-zA-Z1-9_-]+) from final url as ([a-zA-Z0-9]+)$`, client.PickArgumentFromFinalURL)
 	// Pick json key as key from json response
 	// TODO: allow to pick from Path
 	s.Step(`^(?:I )?pick response json ([a-zA-Z1-9_-]+) as ([a-zA-Z0-9]+)$`, client.PickFromResponseJSONBody)
@@ -86,7 +170,18 @@ func InstallAPI(s *godog.ScenarioContext, client *api.Client) {
 		`(?:I )?pick response html value from tag ([a-z]+[1-9]?) attribute ([a-z]+) as ([A-Za-z0-9]+)(?: with attributes conditions:)?`,
 		client.PickResponseHTMLTag,
 	)
+	// Pick response cookie value
 	s.Step(`^(?:I )?pick response cookie ([a-zA-Z1-9_-]+) as ([a-zA-Z0-9]+)$`, client.PickResponseCookie)
+	// Pick response cookie attribute
+	s.Step(
+		`^(?:I )?pick response cookie ([a-zA-Z1-9_-]+) (value|domain|path|expires|max-age|same-site|secure|http-only) as ([a-zA-Z0-9]+)$`,
+		client.PickResponseCookieAttribute,
+	)
+	// Pick multiple values using a `path | as | scope | type` table
+	s.Step(`^(?:I )?pick from json response:$`, func(table *godog.Table) error {
+		return client.PickFromResponse(api.JSONSource, table)
+	})
+	s.Step(`^(?:I )?pick from response (headers|cookies):$`, client.PickFromResponse)
 
 	// s.Step(`^(?:I )?set request cookie from ([a-zA-Z0-9]+)$`, client.SetRequestCookie)
 
@@ -94,6 +189,12 @@ func InstallAPI(s *godog.ScenarioContext, client *api.Client) {
 
 	// Check HTTP status has correct code
 	s.Step(`^response status code should be (\d+)$`, client.ResponseHasStatus)
+	// Check number of redirections followed to obtain response
+	s.Step(`^response should have been redirected (\d+) times?$`, client.ResponseShouldBeRedirectedTimes)
+	// Check redirection hops using a `status | url | location | cookies` table, one row per hop
+	s.Step(`^redirect chain should be:$`, client.RedirectChainShouldBe)
+	// Check URL which answered response. Paths starting with `/` ignore scheme and host
+	s.Step(`^response final url should be ([^ ]+)$`, client.FinalURLShouldBe)
 	// Check is response has empty body
 	s.Step(`^response body should (not )?be empty$`, interfaces.AsNot2(client.EmptyResponseBody))
 	// Check if client has|do not have a cookie X
@@ -112,6 +213,8 @@ func InstallAPI(s *godog.ScenarioContext, client *api.Client) {
 	s.Step(`^response cookie (.+) should (not )?be http only$`, func(name, not string) error {
 		return interfaces.AsNot(client.ResponseCookiesShouldOrShouldNotBeHTTPOnly)(not, name)
 	})
+	// Check response cookies attributes using a `cookie | attribute | matcher | value` table
+	s.Step(`^response cookies should match:$`, client.ResponseCookiesShouldMatch)
 	// Check if response cookie X domain to equal|not equal provided domain
 	s.Step(`^response cookie (.+) domain should (not )?be (.+)$`, func(name, not, domain string) error {
 		return interfaces.AsNot(client.ResponseCookieDomainShouldOrShouldNotMatch)(not, name, domain)
@@ -128,6 +231,57 @@ func InstallAPI(s *godog.ScenarioContext, client *api.Client) {
 			return client.ResponseJSONShouldContain(fully != "", matchPaths)
 		},
 	)
+	// Check json response does not contain paths. Paths accept `*` wildcards (users.*.password)
+	s.Step(`^json response should not contain paths:$`, client.ResponseJSONShouldNotContainPaths)
+	s.Step(`^json response should not contain path ([^ ]+)$`, client.ResponseJSONShouldNotContainPath)
+	// Check if json response object includes provided json (pass as gherkin.DocString) as a recursive subset.
+	// String values can embed matchers as "<<matcher value>>". Arrays can be matched in any order.
+	s.Step(
+		`^json response should include( in any order)?:$`,
+		func(unordered string, expected *godog.DocString) error {
+			return client.ResponseJSONShouldInclude(unordered != "", expected)
+		},
+	)
+
+	// COLLECTIONS ----------------
+	// Arrays are resolved by path in json response. Omit path to target body root.
+	// Conditions are provided as a `field | matcher | value` table applied on each item.
+	s.Step(`^every item of json response(?: ([^ ]+))? should match:$`, client.EveryResponseItemShouldMatch)
+	s.Step(`^at least one item of json response(?: ([^ ]+))? should match:$`, client.AnyResponseItemShouldMatch)
+	s.Step(`^no item of json response(?: ([^ ]+))? should match:$`, client.NoResponseItemShouldMatch)
+	s.Step(`^json response(?: ([^ ]+))? should have (\d+) elements?$`, client.ResponseArrayShouldHaveLength)
+	s.Step(
+		`^json response(?: ([^ ]+))? should be sorted by ([^ ]+)(?: (asc|desc))?$`,
+		func(path, field, order string) error {
+			return client.ResponseArrayShouldBeSortedBy(path, field, order == "desc")
+		},
+	)
+	s.Step(`^json response(?: ([^ ]+))? should have unique ([^ ]+)$`, client.ResponseArrayShouldHaveUnique)
+
+	// Same assertions on picked arrays. Path starts with picked variable name (allOrders.0.lines).
+	s.Step(`^every item of picked ([^ ]+) should match:$`, client.EveryPickedItemShouldMatch)
+	s.Step(`^at least one item of picked ([^ ]+) should match:$`, client.AnyPickedItemShouldMatch)
+	s.Step(`^no item of picked ([^ ]+) should match:$`, client.NoPickedItemShouldMatch)
+	s.Step(`^picked ([^ ]+) should have (\d+) elements?$`, client.PickedArrayShouldHaveLength)
+	s.Step(
+		`^picked ([^ ]+) should be sorted by ([^ ]+)(?: (asc|desc))?$`,
+		func(path, field, order string) error {
+			return client.PickedArrayShouldBeSortedBy(path, field, order == "desc")
+		},
+	)
+	s.Step(`^picked ([^ ]+) should have unique ([^ ]+)$`, client.PickedArrayShouldHaveUnique)
+
+	// PAGINATION ----------------
+	// Follow pagination of a GET endpoint and pick all items as a single array.
+	// Options are provided as a `key | value` table (mode: link|cursor|page|offset, items, cursor,
+	// cursor_param, page_param, start_page, offset_param, limit_param, limit, max_pages).
+	s.Step(`^(?:I )?collect all pages of (.+) as ([a-zA-Z0-9]+) with:$`, client.CollectPages)
+	s.Step(
+		`^(?:I )?collect all pages of (.+) as ([a-zA-Z0-9]+)$`,
+		func(endpoint, pickAs string) error {
+			return client.CollectPages(endpoint, pickAs, nil)
+		},
+	)
 
 	// Try to match html body with provided html code (as gherkin.DocString)
 	s.Step(`^html response should resemble:$`, client.ResponseHTMLShouldBeEquivalent)
@@ -141,6 +295,151 @@ func InstallAPI(s *godog.ScenarioContext, client *api.Client) {
 			return interfaces.AsNot(client.ResponseHeaderShouldOrShouldNotMatch)(not, name, matcher, value)
 		},
 	)
+	// Check every|any|no value of a multi valued response header (Set-Cookie, Vary)
+	s.Step(
+		`^(every|any|no) value of response header (.+) should (equal|contain|match) (.+)$`,
+		client.ResponseHeaderValuesShouldMatch,
+	)
+	// Check response headers using a `key | matcher | value` table
+	s.Step(`^response headers should match:$`, client.ResponseHeadersShouldMatch)
+	// Check response has|has not header X
+	s.Step(`^response should (not )?have header ([a-zA-Z0-9_-]+)$`, func(not, name string) error {
+		return interfaces.AsNot(client.ResponseShouldOrShouldNotHaveHeader)(not, name)
+	})
+
+	// JWT ------------------
+	// Decode a JWT from a literal or picked value
+	s.Step(`^(?:I )?decode jwt (?:from )?([^ ]+)$`, client.DecodeJWT)
+	// Decode a JWT from a response JSON field
+	s.Step(`^(?:I )?decode jwt from response json ([^ ]+)$`, client.DecodeJWTFromResponseJSON)
+	// Decode a JWT from a response header. Leading `Bearer ` is ignored.
+	s.Step(`^(?:I )?decode jwt from response header ([a-zA-Z0-9-]+)$`, client.DecodeJWTFromResponseHeader)
+	// Check decoded JWT header or claims using a `field | matcher | value` table
+	s.Step(`^jwt header should contain:$`, client.JWTHeaderShouldMatch)
+	s.Step(`^jwt claims should contain:$`, client.JWTClaimsShouldMatch)
+	// Verify decoded JWT signature
+	s.Step(`^jwt signature should be valid with secret (.+)$`, client.JWTSignatureShouldBeValidWithSecret)
+	s.Step(`^jwt signature should be valid with jwks file (.+)$`, client.JWTSignatureShouldBeValidWithJWKS)
+	// Check decoded JWT exp/nbf claims relative to now
+	s.Step(`^jwt should be active$`, client.JWTShouldBeActive)
+	s.Step(`^jwt should (not )?be expired$`, interfaces.AsNot2(client.JWTShouldOrShouldNotBeExpired))
+	// Pick a decoded JWT claim
+	s.Step(`^(?:I )?pick jwt claim ([^ ]+) as ([a-zA-Z0-9]+)$`, client.PickJWTClaim)
+
+	// Check encoding used by server to send response body. Body is always decoded before assertions.
+	s.Step(`^response content encoding should be (gzip|deflate|br|brotli|zstd|identity)$`, client.ResponseEncodingShouldBe)
+	// Check response media type and parameters (application/json; charset=utf-8)
+	s.Step(`^response content type should be (.+)$`, client.ResponseContentTypeShouldBe)
+	// Check response body is well formed
+	s.Step(`^response body should be (?:valid|well-formed) (json|xml|html)$`, client.ResponseBodyShouldBeWellFormed)
+	// Check response body is valid UTF-8
+	s.Step(`^response body should be valid utf-8$`, client.ResponseBodyShouldBeValidUTF8)
+	// Check protocol used by server to answer
+	s.Step(`^response protocol should be (HTTP/1\.[01]|HTTP/2(?:\.0)?)$`, client.ResponseProtocolShouldBe)
+
+	// CORS ------------------
+	// Send an OPTIONS preflight. Requested headers are `, ` separated
+	s.Step(
+		`^(?:I )?send (?:a )?CORS preflight to ([^ ]+) from origin ([^ ]+) for ([A-Za-z]+)(?: with headers (.+))?$`,
+		client.SendPreflight,
+	)
+	// Check preflight response using a `key | value` table (origin, methods, headers, credentials, max_age, expose_headers)
+	s.Step(`^CORS should allow:$`, client.CORSShouldAllow)
+	// Check response grants no CORS access
+	s.Step(`^CORS should be rejected$`, client.CORSShouldBeRejected)
+	// Send a preflight and check it. Origin, method and headers are checked by default
+	s.Step(
+		`^CORS preflight to ([^ ]+) from origin ([^ ]+) for ([A-Za-z]+)(?: with headers (.+?))? should allow:$`,
+		client.PreflightShouldAllow,
+	)
+	s.Step(
+		`^CORS preflight to ([^ ]+) from origin ([^ ]+) for ([A-Za-z]+)(?: with headers (.+?))? should be rejected$`,
+		client.PreflightShouldBeRejected,
+	)
+
+	// CONDITIONAL REQUESTS & CACHING ------------------
+	// Store last response ETag and Last-Modified
+	s.Step(`^(?:I )?capture response validators$`, client.CaptureValidators)
+	// Set a conditional header on current request from captured validators
+	s.Step(
+		`^(?:I )?set(?:ting)? (If-None-Match|If-Match|If-Modified-Since|If-Unmodified-Since) request header from captured validators$`,
+		client.SetConditionalHeader,
+	)
+	// Emit last request again with a conditional header from captured validators
+	s.Step(
+		`^(?:I )?replay (?:last )?request with (If-None-Match|If-Match|If-Modified-Since|If-Unmodified-Since)$`,
+		client.ReplayRequestWithCondition,
+	)
+	// Check response is a 304 without body keeping captured ETag
+	s.Step(`^response should be not modified$`, client.ResponseShouldBeNotModified)
+	// Check response is a 412
+	s.Step(`^response should be precondition failed$`, client.ResponseShouldBePreconditionFailed)
+	// Check Cache-Control has|has not a directive
+	s.Step(`^response cache control should (not )?have ([a-z-]+)$`, func(not, directive string) error {
+		return interfaces.AsNot(client.CacheControlShouldOrShouldNotHave)(not, directive)
+	})
+	// Check Cache-Control delta seconds directives
+	s.Step(
+		`^response cache control (max-age|s-maxage|stale-while-revalidate|stale-if-error) should be between (\d+) and (\d+)$`,
+		client.CacheControlAgeShouldBeBetween,
+	)
+	s.Step(
+		`^response cache control (max-age|s-maxage|stale-while-revalidate|stale-if-error) should be at least (\d+)$`,
+		func(directive string, minimum int) error {
+			return client.CacheControlAgeShouldBeBetween(directive, minimum, -1)
+		},
+	)
+	s.Step(
+		`^response cache control (max-age|s-maxage|stale-while-revalidate|stale-if-error) should be at most (\d+)$`,
+		func(directive string, maximum int) error {
+			return client.CacheControlAgeShouldBeBetween(directive, 0, maximum)
+		},
+	)
+
+	// DOWNLOADS ------------------
+	// Stream response bodies to temporary files instead of memory. Streaming stops on reset
+	s.Step(`^(?:I )?stream response bod(?:y|ies) to (?:a )?files?$`, client.StreamResponseBodies)
+	s.Step(`^(?:I )?stop streaming response bod(?:y|ies)$`, client.BufferResponseBodies)
+	// Pick path of the file holding streamed response body
+	s.Step(`^(?:I )?pick downloaded file path as ([a-zA-Z0-9]+)$`, client.PickDownloadPath)
+	// Check response body checksum, size and magic number file type, streamed or not
+	s.Step(`^response body (sha256|md5) should be ([0-9a-fA-F]+)$`, client.ResponseChecksumShouldBe)
+	s.Step(`^response body size should be (\d+) bytes?$`, client.ResponseSizeShouldBe)
+	s.Step(`^response body file type should be ([^ ]+)$`, client.ResponseFileTypeShouldBe)
+	// Request a byte range: 0-99, 100- or -50
+	s.Step(`^(?:I )?set(?:ting)? request range to (?:bytes=)?(\d*-\d*)$`, client.SetRange)
+	// Check response is a 206 holding expected bytes. Total length can be `*`
+	s.Step(
+		`^response should be partial content from (\d+) to (\d+)(?: of (\d+|\*))?$`,
+		client.ResponseShouldBePartialContent,
+	)
+
+	// HYPERMEDIA ------------------
+	// Follow a link from HAL `_links`, JSON:API `links` or Link headers of last response. Method defaults to GET
+	s.Step(
+		`^(?:I )?follow (?:the )?"([^"]+)" link(?: (?:using|with) (GET|PUT|POST|PATCH|DELETE))?$`,
+		client.FollowLink,
+	)
+	// Follow a link using a `.` separated path to nested resources links, e.g. orders.0.self
+	s.Step(`^(?:I )?follow link ([^ ]+)(?: (?:using|with) (GET|PUT|POST|PATCH|DELETE))?$`, client.FollowLink)
+	// Pick resolved link target
+	s.Step(`^(?:I )?pick (?:the )?"([^"]+)" link as ([a-zA-Z0-9]+)$`, client.PickLink)
+	s.Step(`^(?:I )?pick link ([^ ]+) as ([a-zA-Z0-9]+)$`, client.PickLink)
+
+	// PROBLEM DETAILS ------------------
+	// Check response is a RFC 9457 problem details, optionally matching members with a `field | matcher | value` table
+	s.Step(`^response should be a problem$`, func() error {
+		return client.ResponseShouldBeAProblem(nil)
+	})
+	s.Step(`^response should be a problem with:$`, client.ResponseShouldBeAProblem)
+	// Check problem `errors` member lists an entry for each row, columns naming entry members
+	s.Step(`^problem errors should contain:$`, client.ProblemErrorsShouldContain)
+
+	// SECURITY HEADERS ------------------
+	// Define a named security header policy from a `key | value` table overriding default policy
+	s.Step(`^(?:I )?define security header policy ([^ ]+):$`, client.DefineSecurityPolicy)
+	// Check response headers against a named policy (default, api or defined ones)
+	s.Step(`^response should follow security header policy ([^ ]+)$`, client.ResponseShouldFollowSecurityPolicy)
 
 	// OTHERS ------------------
 	// Allow trace debug on client.
@@ -153,3 +452,19 @@ func InstallAPI(s *godog.ScenarioContext, client *api.Client) {
 		return ctx, nil
 	})
 }
+
+// setTimeout sets request timeout from an optional step parameter.
+func setTimeout(client *api.Client, timeout string) error {
+	if timeout == "" {
+		return nil
+	}
+
+	seconds, err := strconv.ParseFloat(timeout, 64)
+	if err != nil {
+		return err
+	}
+
+	client.SetRequestTimeout(seconds)
+
+	return nil
+}
@@ -38,6 +38,13 @@ var (
 	// ErrInvalidCookieDomain is thrown when cookie domain does not match expected.
 	ErrInvalidCookieDomain = errors.New("cookie domain does not match expected")
 
+	// ErrInvalidEncoding is thrown when response content encoding does not
+	// match expected.
+	ErrInvalidEncoding = errors.New("content encoding does not match expected")
+
+	// ErrInvalidProtocol is thrown when response protocol does not match expected.
+	ErrInvalidProtocol = errors.New("protocol does not match expected")
+
 	// ErrInvalidArgNumber is thrown when assertions methods receive an
 	// unexpected number of arguments on periodic argument.
 	ErrInvalidArgNumber = errors.New("invalid quantity for periodic arguments")
@@ -55,6 +62,24 @@ var (
 	// ErrNoMatch is thrown when assertions fails to match expected value.
 	ErrNoMatch = api.ErrNoMatch
 
+	// ErrMissingHeader is thrown when an expected header is absent.
+	ErrMissingHeader = api.ErrMissingHeader
+
+	// ErrUnexpectedHeader is thrown when a header expected to be absent exists.
+	ErrUnexpectedHeader = api.ErrUnexpectedHeader
+
+	// ErrUnexpectedPath is thrown when a path expected to be absent exists.
+	ErrUnexpectedPath = api.ErrUnexpectedPath
+
+	// ErrContentType is thrown when response Content-Type does not match expected.
+	ErrContentType = api.ErrContentType
+
+	// ErrMalformedBody is thrown when response body is not well formed.
+	ErrMalformedBody = api.ErrMalformedBody
+
+	// ErrInvalidUTF8 is thrown when response body is not valid UTF-8.
+	ErrInvalidUTF8 = api.ErrInvalidUTF8
+
 	// ErrNotFullyMatch is thrown when actual asserted object contains
 	// more values than expected.
 	ErrNotFullyMatch = api.ErrNotFullyMatch
@@ -66,6 +91,10 @@ var (
 	// ErrNoRequest is thrown when assertion expected a request to exists
 	// but none exists.
 	ErrNoRequest = api.ErrNoRequest
+
+	// ErrNotIncluded is thrown when expected JSON is not a subset of
+	// response JSON.
+	ErrNotIncluded = api.ErrNotIncluded
 )
 
 // ResponseHasStatus asserts Response has expected status.
@@ -220,6 +249,16 @@ func (cli *Client) ResponseJSONShouldContain(fully bool, matchPaths *godog.Table
 	return cli.cli.Response.JSONContains(fully, matchPaths)
 }
 
+// ResponseJSONShouldInclude asserts response body is a JSON including provided JSON.
+// Objects are matched as subsets and string values can embed a matcher
+// expression as `<<matcher value>>`. If unordered is true, arrays elements
+// are matched whatever their position.
+//
+//	{"id": "<<defined>>", "createdAt": "<<match ^\\d{4}->>"}
+func (cli *Client) ResponseJSONShouldInclude(unordered bool, expected *godog.DocString) error {
+	return cli.cli.Response.JSONIncludes(expected, unordered)
+}
+
 // ResponseHTMLShouldBeEquivalent asserts response body is a HTML resembling provided.
 func (cli *Client) ResponseHTMLShouldBeEquivalent(body *godog.DocString) error {
 	return cli.cli.Response.HTMLResemble(body)
@@ -230,6 +269,70 @@ func (cli *Client) ResponseHTMLShouldContains(elements *godog.Table) error {
 	return cli.cli.Response.HTMLContain(elements)
 }
 
+// ResponseEncodingShouldBe asserts server used expected content encoding
+// (gzip, deflate, br, zstd or identity) to send response body.
+func (cli *Client) ResponseEncodingShouldBe(encoding string) error {
+	expected := api.NormalizeEncoding(encoding)
+
+	if cli.cli.Response.ContentEncoding != expected {
+		return fmt.Errorf("%w: expected %s, got %s", ErrInvalidEncoding, expected, cli.cli.Response.ContentEncoding)
+	}
+
+	return nil
+}
+
+// ResponseJSONShouldNotContainPaths asserts none of listed paths exist in
+// response JSON body. Table lists one path per row with an optional `path`
+// header. Paths accept `*` wildcards matching any key or index (`users.*.password`).
+func (cli *Client) ResponseJSONShouldNotContainPaths(paths *godog.Table) error {
+	var list []string
+
+	for i, row := range paths.Rows {
+		path := row.Cells[0].Value
+		if i == 0 && (path == "path" || path == "field") {
+			continue
+		}
+
+		list = append(list, path)
+	}
+
+	return cli.cli.Response.JSONPathsAbsent(list)
+}
+
+// ResponseJSONShouldNotContainPath asserts path does not exist in response JSON body.
+func (cli *Client) ResponseJSONShouldNotContainPath(path string) error {
+	return cli.cli.Response.JSONPathsAbsent([]string{path})
+}
+
+// ResponseContentTypeShouldBe asserts response Content-Type media type
+// and provided parameters, e.g. `application/json; charset=utf-8`.
+func (cli *Client) ResponseContentTypeShouldBe(contentType string) error {
+	return cli.cli.Response.ContentTypeMatches(contentType)
+}
+
+// ResponseBodyShouldBeWellFormed asserts response body is well formed
+// json, xml or html. Errors report position of first syntax error.
+func (cli *Client) ResponseBodyShouldBeWellFormed(format string) error {
+	return cli.cli.Response.IsWellFormed(format)
+}
+
+// ResponseBodyShouldBeValidUTF8 asserts response body is valid UTF-8.
+func (cli *Client) ResponseBodyShouldBeValidUTF8() error {
+	return cli.cli.Response.IsValidUTF8()
+}
+
+// ResponseProtocolShouldBe asserts server answered using expected protocol,
+// e.g. HTTP/1.1 or HTTP/2.0.
+func (cli *Client) ResponseProtocolShouldBe(protocol string) error {
+	expected := api.NormalizeProtocol(protocol)
+
+	if cli.cli.Response.Protocol != expected {
+		return fmt.Errorf("%w: expected %s, got %s", ErrInvalidProtocol, expected, cli.cli.Response.Protocol)
+	}
+
+	return nil
+}
+
 // ResponseHeaderShouldOrShouldNotMatch asserts response header
 // match or does not match provided value using provided matcher.
 func (cli *Client) ResponseHeaderShouldOrShouldNotMatch(not bool, params ...string) error {
@@ -241,7 +344,49 @@ func (cli *Client) ResponseHeaderShouldOrShouldNotMatch(not bool, params ...stri
 	matcher := params[1]
 	value := params[2]
 
-	header := cli.cli.Response.RetrieveHeader(name)
+	if !cli.cli.Response.HasHeader(name) {
+		if not {
+			return nil
+		}
+
+		// keep matching absent headers as empty values.
+		return match.Assert(matcher, "", value)
+	}
+
+	quantifier := api.AnyValue
+	if not {
+		quantifier = api.NoValue
+	}
+
+	return cli.cli.Response.HeaderValuesMatch(name, quantifier, matcher, value)
+}
+
+// ResponseHeaderValuesShouldMatch asserts every, any or no value of a
+// multi valued header (Set-Cookie, Vary) matches value using matcher.
+func (cli *Client) ResponseHeaderValuesShouldMatch(quantifier, name, matcher, value string) error {
+	return cli.cli.Response.HeaderValuesMatch(name, quantifier, matcher, value)
+}
+
+// ResponseHeadersShouldMatch asserts response headers match
+// a `key | matcher | value` table.
+func (cli *Client) ResponseHeadersShouldMatch(expected *godog.Table) error {
+	return cli.cli.Response.HeaderMatches(expected)
+}
+
+// ResponseShouldOrShouldNotHaveHeader asserts response has or has not header name.
+func (cli *Client) ResponseShouldOrShouldNotHaveHeader(not bool, params ...string) error {
+	if len(params) != 1 {
+		return fmt.Errorf("%w: expected header name", ErrInvalidArgNumber)
+	}
+
+	has := cli.cli.Response.HasHeader(params[0])
 
-	return match.Assert(matcher, header, value)
+	switch {
+	case not && has:
+		return fmt.Errorf("%w: %s", ErrUnexpectedHeader, params[0])
+	case !not && !has:
+		return fmt.Errorf("%w: %s", ErrMissingHeader, params[0])
+	}
+
+	return nil
 }
@@ -0,0 +1,125 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/elmagician/kactus/internal/api"
+)
+
+const percent = 100
+
+// ErrBurst is thrown when burst results do not match expected.
+var ErrBurst = errors.New("burst results do not match expected")
+
+// Exposes burst errors
+var (
+	// ErrInvalidBurst is thrown when burst parameters are not valid.
+	ErrInvalidBurst = api.ErrInvalidBurst
+
+	// ErrNoBurst is thrown when asserting on burst results before sending one.
+	ErrNoBurst = api.ErrNoBurst
+)
+
+// SendBurst sends currently prepared request count times using workers
+// concurrent workers. Every status and latency is kept for burst assertions.
+func (cli *Client) SendBurst(count, workers int) error {
+	report, err := cli.cli.Burst(cli.request, count, workers)
+	if err != nil {
+		return err
+	}
+
+	cli.burst = report
+
+	if cli.autoResetRequest {
+		cli.ResetRequest()
+	}
+
+	return nil
+}
+
+// BurstShouldHaveNoErrors asserts every burst request received a response.
+func (cli *Client) BurstShouldHaveNoErrors() error {
+	if cli.burst == nil {
+		return ErrNoBurst
+	}
+
+	if errs := cli.burst.Errors(); len(errs) > 0 {
+		return fmt.Errorf("%w: %d requests failed, first error: %s", ErrBurst, len(errs), errs[0].Error())
+	}
+
+	return nil
+}
+
+// BurstStatusCountShouldBe asserts exactly count burst responses have status.
+func (cli *Client) BurstStatusCountShouldBe(count, status int) error {
+	if cli.burst == nil {
+		return ErrNoBurst
+	}
+
+	if actual := cli.burst.StatusCount(status); actual != count {
+		return fmt.Errorf(
+			"%w: expected %d responses with status %d, got %d (distribution %v)",
+			ErrBurst, count, status, actual, cli.burst.StatusDistribution(),
+		)
+	}
+
+	return nil
+}
+
+// BurstStatusShouldBeAtLeast asserts at least minimum burst responses have status.
+// If inPercent is true, minimum is a percentage of sent requests.
+func (cli *Client) BurstStatusShouldBeAtLeast(minimum int, inPercent bool, status int) error {
+	if cli.burst == nil {
+		return ErrNoBurst
+	}
+
+	expected := float64(minimum)
+	if inPercent {
+		expected = float64(minimum*len(cli.burst.Results)) / percent
+	}
+
+	if actual := cli.burst.StatusCount(status); float64(actual) < expected {
+		return fmt.Errorf(
+			"%w: expected at least %.0f responses with status %d, got %d (distribution %v)",
+			ErrBurst, expected, status, actual, cli.burst.StatusDistribution(),
+		)
+	}
+
+	return nil
+}
+
+// EveryBurstStatusShouldBe asserts all burst responses have status.
+func (cli *Client) EveryBurstStatusShouldBe(status int) error {
+	if cli.burst == nil {
+		return ErrNoBurst
+	}
+
+	return cli.BurstStatusCountShouldBe(len(cli.burst.Results), status)
+}
+
+// BurstLatencyShouldBeUnder asserts latency percentile is below maximum duration.
+// Percentile is provided as p50, p95, p99... Failed requests are ignored.
+func (cli *Client) BurstLatencyShouldBeUnder(percentile, maximum string) error {
+	if cli.burst == nil {
+		return ErrNoBurst
+	}
+
+	var rank float64
+	if _, err := fmt.Sscanf(strings.ToLower(percentile), "p%g", &rank); err != nil || rank <= 0 || rank > percent {
+		return fmt.Errorf("%w: invalid percentile %s", ErrInvalidBurst, percentile)
+	}
+
+	limit, err := time.ParseDuration(maximum)
+	if err != nil {
+		return err
+	}
+
+	if actual := cli.burst.Percentile(rank); actual >= limit {
+		return fmt.Errorf("%w: %s latency is %s, expected under %s", ErrBurst, percentile, actual, limit)
+	}
+
+	return nil
+}
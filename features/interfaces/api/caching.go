@@ -0,0 +1,78 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/elmagician/kactus/internal/api"
+)
+
+// Exposes conditional requests and caching errors
+var (
+	// ErrNoValidator is thrown when no ETag or Last-Modified validator is available.
+	ErrNoValidator = api.ErrNoValidator
+
+	// ErrConditional is thrown when a conditional request is not answered as expected.
+	ErrConditional = api.ErrConditional
+
+	// ErrCacheControl is thrown when Cache-Control does not match expected.
+	ErrCacheControl = api.ErrCacheControl
+)
+
+// CaptureValidators stores last response ETag and Last-Modified to build
+// conditional requests.
+func (cli *Client) CaptureValidators() error {
+	_, err := cli.cli.CaptureValidators()
+	return err
+}
+
+// SetConditionalHeader sets a conditional header (If-None-Match, If-Match,
+// If-Modified-Since or If-Unmodified-Since) on current request from
+// captured validators.
+func (cli *Client) SetConditionalHeader(header string) error {
+	if cli.request.Empty() {
+		cli.InitRequest(true)
+	}
+
+	req, err := cli.cli.SetCondition(cli.request, header)
+	if err != nil {
+		return err
+	}
+
+	cli.request = req
+
+	return nil
+}
+
+// ReplayRequestWithCondition emits last request again with a conditional
+// header built from captured validators.
+func (cli *Client) ReplayRequestWithCondition(header string) error {
+	return cli.cli.Replay(header)
+}
+
+// ResponseShouldBeNotModified asserts response is a 304 Not Modified
+// without body keeping captured ETag.
+func (cli *Client) ResponseShouldBeNotModified() error {
+	return cli.cli.Response.IsNotModified(cli.cli.CapturedValidators())
+}
+
+// ResponseShouldBePreconditionFailed asserts response is a 412 Precondition Failed.
+func (cli *Client) ResponseShouldBePreconditionFailed() error {
+	return cli.ResponseHasStatus(http.StatusPreconditionFailed)
+}
+
+// CacheControlShouldOrShouldNotHave asserts response Cache-Control has or has
+// not directive, e.g. no-store or private.
+func (cli *Client) CacheControlShouldOrShouldNotHave(not bool, params ...string) error {
+	if len(params) != 1 {
+		return fmt.Errorf("%w: expected cache control directive", ErrInvalidArgNumber)
+	}
+
+	return cli.cli.Response.CacheControlHas(not, params[0])
+}
+
+// CacheControlAgeShouldBeBetween asserts a Cache-Control delta seconds
+// directive (max-age, s-maxage) is within [minimum, maximum].
+func (cli *Client) CacheControlAgeShouldBeBetween(directive string, minimum, maximum int) error {
+	return cli.cli.Response.CacheControlAgeBetween(directive, minimum, maximum)
+}
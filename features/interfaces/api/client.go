@@ -1,6 +1,7 @@
 package api
 
 import (
+	"io/ioutil"
 	"net/http"
 
 	"github.com/elmagician/kactus/internal/api"
@@ -13,6 +14,11 @@ type Client struct {
 	cli   *api.Client
 
 	request api.RequestPreparation
+	jwt     *api.JWT
+	burst   *api.BurstReport
+
+	// policies are kept on Reset.
+	policies map[string]api.SecurityPolicy
 
 	autoResetRequest bool
 	resetAutoRequest bool
@@ -32,6 +38,10 @@ func New(store *internalPicker.Store, autoReset bool) (*Client, error) {
 		cli:              cli,
 		autoResetRequest: autoReset,
 		resetAutoRequest: autoReset,
+		policies: map[string]api.SecurityPolicy{
+			DefaultSecurityPolicy: api.DefaultSecurityPolicy(),
+			APISecurityPolicy:     api.APISecurityPolicy(),
+		},
 	}, nil
 }
 
@@ -49,6 +59,8 @@ func (cli *Client) ResetRequest() {
 func (cli *Client) Reset() {
 	cli.cli.Reset()
 	cli.ResetRequest()
+	cli.jwt = nil
+	cli.burst = nil
 	cli.autoResetRequest = cli.resetAutoRequest
 }
 
@@ -80,6 +92,40 @@ func (cli *Client) DisableRedirect() {
 	cli.cli.SetFollowRedirection(false)
 }
 
+// UseProtocol forces protocol used to emit requests: HTTP/1.1, HTTP/2 over TLS
+// or h2c (cleartext HTTP/2 with prior knowledge). Use default to negotiate.
+func (cli *Client) UseProtocol(protocol string) error {
+	return cli.cli.SetProtocol(protocol)
+}
+
+// TrustCertificate trusts PEM encoded certificate authorities read from file,
+// e.g. to reach servers using self-signed certificates.
+func (cli *Client) TrustCertificate(path string) error {
+	certificates, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	return cli.cli.TrustCertificates(certificates)
+}
+
+// SkipTLSVerification disables server certificate verification.
+func (cli *Client) SkipTLSVerification() error {
+	return cli.cli.SetInsecureSkipVerify(true)
+}
+
+// UseUnixSocket emits every request on Unix domain socket at path, keeping
+// endpoints path and query. Endpoints can also target a socket directly
+// using `unix:///var/run/app.sock:/v1/status`.
+func (cli *Client) UseUnixSocket(path string) {
+	cli.cli.SetUnixSocket(path)
+}
+
+// DisableUnixSocket emits requests on endpoints host again.
+func (cli *Client) DisableUnixSocket() {
+	cli.cli.SetUnixSocket("")
+}
+
 // ExecuteRequest builds and executes request through http client.
 func (cli *Client) ExecuteRequest() error {
 	if err := cli.cli.EmitRequest(cli.request); err != nil {
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"github.com/cucumber/godog"
+
+	"github.com/elmagician/kactus/internal/api"
+)
+
+// Exposes codec errors
+var (
+	// ErrUnknownCodec is thrown when no codec matches a body format.
+	ErrUnknownCodec = api.ErrUnknownCodec
+
+	// ErrUnknownMessage is thrown when a protobuf message is not in provided descriptors.
+	ErrUnknownMessage = api.ErrUnknownMessage
+)
+
+// SetEncodedBody replaces current request body with a JSON document
+// encoded to format (msgpack or cbor).
+func (cli *Client) SetEncodedBody(format string, body *godog.DocString) error {
+	codec, err := api.CodecFor(format)
+	if err != nil {
+		return err
+	}
+
+	cli.ClearBody()
+	cli.request, err = cli.request.SetEncodedBody(codec, body)
+
+	return err
+}
+
+// SetProtobufBody replaces current request body with a JSON document
+// encoded as protobuf message. Message type is loaded from a binary
+// FileDescriptorSet file generated by `protoc --include_imports --descriptor_set_out`.
+func (cli *Client) SetProtobufBody(message, descriptorSet string, body *godog.DocString) error {
+	codec, err := api.NewProtobufCodec(descriptorSet, message)
+	if err != nil {
+		return err
+	}
+
+	cli.ClearBody()
+	cli.request, err = cli.request.SetEncodedBody(codec, body)
+
+	return err
+}
+
+// DecodeResponseAsProtobuf decodes current response body as protobuf message
+// for following JSON assertions and pickers.
+func (cli *Client) DecodeResponseAsProtobuf(message, descriptorSet string) error {
+	if cli.cli.Response == nil {
+		return api.ErrNoBody
+	}
+
+	codec, err := api.NewProtobufCodec(descriptorSet, message)
+	if err != nil {
+		return err
+	}
+
+	cli.cli.Response.Codec = codec
+
+	return nil
+}
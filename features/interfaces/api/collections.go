@@ -0,0 +1,169 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cucumber/godog"
+
+	"github.com/elmagician/kactus/internal/api"
+	"github.com/elmagician/kactus/internal/interfaces"
+)
+
+// ErrNotPicked is thrown when a collection assertion targets an unknown picked variable.
+var ErrNotPicked = errors.New("unpicked value")
+
+// Exposes collection errors
+var (
+	// ErrNotAnArray is thrown when a collection assertion targets a non array value.
+	ErrNotAnArray = api.ErrNotAnArray
+
+	// ErrCollection is thrown when a collection does not satisfy assertion.
+	ErrCollection = api.ErrCollection
+
+	// ErrNotComparable is thrown when collection values cannot be ordered.
+	ErrNotComparable = api.ErrNotComparable
+)
+
+// EveryResponseItemShouldMatch asserts each item of the response JSON array under path matches conditions.
+func (cli *Client) EveryResponseItemShouldMatch(path string, expected *godog.Table) error {
+	items, err := cli.cli.Response.RetrieveJSONArray(path)
+	if err != nil {
+		return err
+	}
+
+	return api.EveryItemMatches(items, expected)
+}
+
+// AnyResponseItemShouldMatch asserts at least one item of the response JSON array under path matches conditions.
+func (cli *Client) AnyResponseItemShouldMatch(path string, expected *godog.Table) error {
+	items, err := cli.cli.Response.RetrieveJSONArray(path)
+	if err != nil {
+		return err
+	}
+
+	return api.AnyItemMatches(items, expected)
+}
+
+// NoResponseItemShouldMatch asserts no item of the response JSON array under path matches conditions.
+func (cli *Client) NoResponseItemShouldMatch(path string, expected *godog.Table) error {
+	items, err := cli.cli.Response.RetrieveJSONArray(path)
+	if err != nil {
+		return err
+	}
+
+	return api.NoItemMatches(items, expected)
+}
+
+// ResponseArrayShouldHaveLength asserts response JSON array under path has expected length.
+func (cli *Client) ResponseArrayShouldHaveLength(path string, length int) error {
+	items, err := cli.cli.Response.RetrieveJSONArray(path)
+	if err != nil {
+		return err
+	}
+
+	return api.HasLength(items, length)
+}
+
+// ResponseArrayShouldBeSortedBy asserts response JSON array under path is sorted by field value.
+func (cli *Client) ResponseArrayShouldBeSortedBy(path, field string, descending bool) error {
+	items, err := cli.cli.Response.RetrieveJSONArray(path)
+	if err != nil {
+		return err
+	}
+
+	return api.IsSortedBy(items, field, descending)
+}
+
+// ResponseArrayShouldHaveUnique asserts field value is unique among response JSON array items.
+func (cli *Client) ResponseArrayShouldHaveUnique(path, field string) error {
+	items, err := cli.cli.Response.RetrieveJSONArray(path)
+	if err != nil {
+		return err
+	}
+
+	return api.HasUnique(items, field)
+}
+
+// EveryPickedItemShouldMatch asserts each item of the picked array under path matches conditions.
+func (cli *Client) EveryPickedItemShouldMatch(path string, expected *godog.Table) error {
+	items, err := cli.pickedArray(path)
+	if err != nil {
+		return err
+	}
+
+	return api.EveryItemMatches(items, expected)
+}
+
+// AnyPickedItemShouldMatch asserts at least one item of the picked array under path matches conditions.
+func (cli *Client) AnyPickedItemShouldMatch(path string, expected *godog.Table) error {
+	items, err := cli.pickedArray(path)
+	if err != nil {
+		return err
+	}
+
+	return api.AnyItemMatches(items, expected)
+}
+
+// NoPickedItemShouldMatch asserts no item of the picked array under path matches conditions.
+func (cli *Client) NoPickedItemShouldMatch(path string, expected *godog.Table) error {
+	items, err := cli.pickedArray(path)
+	if err != nil {
+		return err
+	}
+
+	return api.NoItemMatches(items, expected)
+}
+
+// PickedArrayShouldHaveLength asserts picked array under path has expected length.
+func (cli *Client) PickedArrayShouldHaveLength(path string, length int) error {
+	items, err := cli.pickedArray(path)
+	if err != nil {
+		return err
+	}
+
+	return api.HasLength(items, length)
+}
+
+// PickedArrayShouldBeSortedBy asserts picked array under path is sorted by field value.
+func (cli *Client) PickedArrayShouldBeSortedBy(path, field string, descending bool) error {
+	items, err := cli.pickedArray(path)
+	if err != nil {
+		return err
+	}
+
+	return api.IsSortedBy(items, field, descending)
+}
+
+// PickedArrayShouldHaveUnique asserts field value is unique among picked array items.
+func (cli *Client) PickedArrayShouldHaveUnique(path, field string) error {
+	items, err := cli.pickedArray(path)
+	if err != nil {
+		return err
+	}
+
+	return api.HasUnique(items, field)
+}
+
+// pickedArray resolves an array from picked values. Path first element
+// is the picked variable name, e.g. `allOrders` or `allOrders.0.lines`.
+func (cli *Client) pickedArray(path string) ([]interface{}, error) {
+	parts := strings.SplitN(path, ".", 2) // nolint: gomnd
+
+	value, exists := cli.store.Get(parts[0])
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrNotPicked, parts[0])
+	}
+
+	if len(parts) > 1 {
+		field, ok := interfaces.GetFieldFromPath(value, parts[1])
+		if !ok || !field.IsValid() {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownKey, path)
+		}
+
+		value = field.Interface()
+	}
+
+	return api.AsArray(value)
+}
@@ -0,0 +1,37 @@
+package api
+
+import (
+	"github.com/cucumber/godog"
+
+	"github.com/elmagician/kactus/internal/api"
+)
+
+var (
+	// ErrMissingCookie is thrown when response does not set an expected cookie.
+	ErrMissingCookie = api.ErrMissingCookie
+	// ErrUnknownSession is thrown when restoring a cookie session which was not saved.
+	ErrUnknownSession = api.ErrUnknownSession
+)
+
+// ResponseCookiesShouldMatch asserts cookies set by response using a
+// `cookie | attribute | matcher | value` table. attribute defaults to value
+// and can be domain, path, expires, max-age, same-site, secure or http-only.
+func (cli *Client) ResponseCookiesShouldMatch(expected *godog.Table) error {
+	return cli.cli.Response.CookiesMatch(expected)
+}
+
+// SaveCookieSession saves client cookies as a named session which
+// can be restored in later scenarios, e.g. to log in once per feature.
+func (cli *Client) SaveCookieSession(name string) error {
+	return cli.cli.SaveSession(name)
+}
+
+// RestoreCookieSession replaces client cookies by a saved session.
+func (cli *Client) RestoreCookieSession(name string) error {
+	return cli.cli.RestoreSession(name)
+}
+
+// ForgetCookieSession deletes a saved cookie session.
+func (cli *Client) ForgetCookieSession(name string) {
+	cli.cli.ForgetSession(name)
+}
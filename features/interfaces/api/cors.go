@@ -0,0 +1,88 @@
+package api
+
+import (
+	"strings"
+
+	"github.com/cucumber/godog"
+
+	"github.com/elmagician/kactus/internal/api"
+)
+
+// ErrCORS is thrown when a response does not follow expected CORS policy.
+var ErrCORS = api.ErrCORS
+
+// SendPreflight emits a CORS preflight OPTIONS request to endpoint from origin
+// for method. Requested headers are provided as a `, ` separated list.
+// Current request preparation is left untouched.
+func (cli *Client) SendPreflight(endpoint, origin, method, headers string) error {
+	return cli.cli.Preflight(endpoint, origin, method, splitHeaders(headers))
+}
+
+// CORSShouldAllow asserts last response CORS headers allow options
+// provided as a `key | value` table (origin, methods, headers,
+// credentials, max_age, expose_headers).
+func (cli *Client) CORSShouldAllow(expected *godog.Table) error {
+	options, err := api.OptionsFromTable(expected)
+	if err != nil {
+		return err
+	}
+
+	return cli.cli.Response.CORSAllows(options)
+}
+
+// CORSShouldBeRejected asserts last response grants no CORS access.
+func (cli *Client) CORSShouldBeRejected() error {
+	return cli.cli.Response.HasNoCORSHeaders()
+}
+
+// PreflightShouldAllow emits a CORS preflight and asserts response allows
+// options provided as a `key | value` table. Origin, requested method and
+// headers are checked by default, unless CORS-safelisted.
+func (cli *Client) PreflightShouldAllow(endpoint, origin, method, headers string, expected *godog.Table) error {
+	if err := cli.SendPreflight(endpoint, origin, method, headers); err != nil {
+		return err
+	}
+
+	options, err := api.OptionsFromTable(expected)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := options["origin"]; !ok {
+		options["origin"] = origin
+	}
+
+	method, list := api.NonSafelisted(method, splitHeaders(headers))
+
+	if _, ok := options["methods"]; !ok && method != "" {
+		options["methods"] = method
+	}
+
+	if _, ok := options["headers"]; !ok && len(list) > 0 {
+		options["headers"] = strings.Join(list, ", ")
+	}
+
+	return cli.cli.Response.CORSAllows(options)
+}
+
+// PreflightShouldBeRejected emits a CORS preflight and asserts response
+// grants no CORS access.
+func (cli *Client) PreflightShouldBeRejected(endpoint, origin, method, headers string) error {
+	if err := cli.SendPreflight(endpoint, origin, method, headers); err != nil {
+		return err
+	}
+
+	return cli.CORSShouldBeRejected()
+}
+
+func splitHeaders(headers string) []string {
+	var list []string
+
+	for _, header := range strings.Split(headers, ",") {
+		if header = strings.TrimSpace(header); header != "" {
+			list = append(list, header)
+		}
+	}
+
+	return list
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"github.com/elmagician/kactus/internal/api"
+	internalPicker "github.com/elmagician/kactus/internal/picker"
+)
+
+// Exposes download errors
+var (
+	// ErrChecksum is thrown when response body checksum does not match expected.
+	ErrChecksum = api.ErrChecksum
+
+	// ErrSize is thrown when response body size does not match expected.
+	ErrSize = api.ErrSize
+
+	// ErrFileType is thrown when response body file type does not match expected.
+	ErrFileType = api.ErrFileType
+
+	// ErrPartialContent is thrown when response is not the expected partial content.
+	ErrPartialContent = api.ErrPartialContent
+
+	// ErrNoDownload is thrown when an assertion does not apply to the way
+	// response body was received: streamed to a file or buffered in memory.
+	ErrNoDownload = api.ErrNoDownload
+)
+
+// StreamResponseBodies writes next response bodies to temporary files instead
+// of memory. Body assertions and picking steps fail with ErrNoDownload on
+// streamed bodies: use checksum, size and file type ones. Streaming stops on reset.
+func (cli *Client) StreamResponseBodies() {
+	cli.cli.SetStreaming(true)
+}
+
+// BufferResponseBodies stops streaming response bodies to files.
+func (cli *Client) BufferResponseBodies() {
+	cli.cli.SetStreaming(false)
+}
+
+// PickDownloadPath picks path of the file holding streamed response body.
+func (cli *Client) PickDownloadPath(pickAs string) error {
+	path, err := cli.cli.Response.DownloadPath()
+	if err != nil {
+		return err
+	}
+
+	cli.store.Pick(pickAs, path, internalPicker.DisposableValue)
+
+	return nil
+}
+
+// ResponseChecksumShouldBe asserts response body sha256 or md5 hex digest.
+func (cli *Client) ResponseChecksumShouldBe(algorithm, expected string) error {
+	return cli.cli.Response.ChecksumIs(algorithm, expected)
+}
+
+// ResponseSizeShouldBe asserts response body size in bytes.
+func (cli *Client) ResponseSizeShouldBe(expected int) error {
+	return cli.cli.Response.SizeIs(int64(expected))
+}
+
+// ResponseFileTypeShouldBe asserts response body file type from its magic
+// number, e.g. pdf, png, zip or a media type such as image/png.
+func (cli *Client) ResponseFileTypeShouldBe(expected string) error {
+	return cli.cli.Response.FileTypeIs(expected)
+}
+
+// SetRange requests a byte range, e.g. `0-99`, `100-` or `-50`.
+func (cli *Client) SetRange(byteRange string) {
+	if cli.request.Empty() {
+		cli.InitRequest(true)
+	}
+
+	cli.request = cli.request.SetRange(byteRange)
+}
+
+// ResponseShouldBePartialContent asserts response is a 206 holding bytes
+// first to last of a representation. Total is checked when not empty.
+func (cli *Client) ResponseShouldBePartialContent(first, last int, total string) error {
+	return cli.cli.Response.IsPartialContent(int64(first), int64(last), total)
+}
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/elmagician/kactus/internal/api"
+	internalPicker "github.com/elmagician/kactus/internal/picker"
+)
+
+// ErrNoLink is thrown when last response does not provide a link relation.
+var ErrNoLink = api.ErrNoLink
+
+// FollowLink emits a request on a link relation provided by last response
+// through HAL `_links`, JSON:API `links` or Link headers. Nested resources
+// links are targeted with `.` separated paths, e.g. `orders.0.self`.
+//
+// Current request preparation (headers, body) is used. Method defaults to GET.
+// Prepared query arguments are replaced by link query.
+func (cli *Client) FollowLink(rel, method string) error {
+	target, err := cli.cli.ResolveLink(rel)
+	if err != nil {
+		return err
+	}
+
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	cli.SetEndpoint(target)
+
+	if err := cli.SetMethod(method); err != nil {
+		return err
+	}
+
+	cli.request = cli.request.ResetArguments()
+
+	return cli.ExecuteRequest()
+}
+
+// PickLink picks resolved target of a link relation provided by last response.
+func (cli *Client) PickLink(rel, pickAs string) error {
+	target, err := cli.cli.ResolveLink(rel)
+	if err != nil {
+		return err
+	}
+
+	cli.store.Pick(pickAs, target, internalPicker.DisposableValue)
+
+	return nil
+}
@@ -0,0 +1,151 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/cucumber/godog"
+
+	"github.com/elmagician/kactus/internal/api"
+	internalPicker "github.com/elmagician/kactus/internal/picker"
+)
+
+// ErrNoJWT is thrown when asserting on a JWT before decoding one.
+var ErrNoJWT = errors.New("no JWT decoded")
+
+// Exposes JWT errors
+var (
+	// ErrInvalidJWT is thrown when a token cannot be decoded as a JWT.
+	ErrInvalidJWT = api.ErrInvalidJWT
+
+	// ErrJWTSignature is thrown when a token signature cannot be verified.
+	ErrJWTSignature = api.ErrJWTSignature
+
+	// ErrJWTTime is thrown when a token is not valid at checked time.
+	ErrJWTTime = api.ErrJWTTime
+)
+
+// DecodeJWT decodes provided token. Use picker injection to decode a picked token.
+// Following JWT assertions will apply on it.
+func (cli *Client) DecodeJWT(token string) error {
+	jwt, err := api.ParseJWT(token)
+	if err != nil {
+		return err
+	}
+
+	cli.jwt = jwt
+
+	return nil
+}
+
+// DecodeJWTFromResponseJSON decodes token stored under path in response JSON body.
+func (cli *Client) DecodeJWTFromResponseJSON(path string) error {
+	value, err := cli.cli.Response.RetrieveJSON(path)
+	if err != nil {
+		return err
+	}
+
+	token, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("%w: %s is not a string", ErrInvalidJWT, path)
+	}
+
+	return cli.DecodeJWT(token)
+}
+
+// DecodeJWTFromResponseHeader decodes token from response header.
+// A leading `Bearer ` is ignored.
+func (cli *Client) DecodeJWTFromResponseHeader(name string) error {
+	return cli.DecodeJWT(cli.cli.Response.RetrieveHeader(name))
+}
+
+// JWTHeaderShouldMatch asserts decoded JWT header matches a `field | matcher | value` table.
+func (cli *Client) JWTHeaderShouldMatch(expected *godog.Table) error {
+	if cli.jwt == nil {
+		return ErrNoJWT
+	}
+
+	return cli.jwt.HeaderMatches(expected)
+}
+
+// JWTClaimsShouldMatch asserts decoded JWT claims match a `field | matcher | value` table.
+func (cli *Client) JWTClaimsShouldMatch(expected *godog.Table) error {
+	if cli.jwt == nil {
+		return ErrNoJWT
+	}
+
+	return cli.jwt.ClaimsMatch(expected)
+}
+
+// JWTSignatureShouldBeValidWithSecret verifies decoded JWT HMAC signature.
+func (cli *Client) JWTSignatureShouldBeValidWithSecret(secret string) error {
+	if cli.jwt == nil {
+		return ErrNoJWT
+	}
+
+	return cli.jwt.VerifyWithSecret([]byte(secret))
+}
+
+// JWTSignatureShouldBeValidWithJWKS verifies decoded JWT signature using a JWKS file.
+func (cli *Client) JWTSignatureShouldBeValidWithJWKS(path string) error {
+	if cli.jwt == nil {
+		return ErrNoJWT
+	}
+
+	set, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	return cli.jwt.VerifyWithJWKS(set)
+}
+
+// JWTShouldBeActive asserts decoded JWT `exp` and `nbf` claims allow
+// token usage now.
+func (cli *Client) JWTShouldBeActive() error {
+	if cli.jwt == nil {
+		return ErrNoJWT
+	}
+
+	return cli.jwt.CheckTime(time.Now(), 0)
+}
+
+// JWTShouldOrShouldNotBeExpired asserts decoded JWT is/isn't expired.
+//
+// Second argument is not used. It is present for
+// interface.AsNot simplification in step definitions.
+func (cli *Client) JWTShouldOrShouldNotBeExpired(not bool, _ ...string) error {
+	if cli.jwt == nil {
+		return ErrNoJWT
+	}
+
+	expired := cli.jwt.IsExpired(time.Now())
+
+	if !not && !expired {
+		return fmt.Errorf("%w: expected token to be expired", ErrJWTTime)
+	}
+
+	if not && expired {
+		return fmt.Errorf("%w: expected token not to be expired", ErrJWTTime)
+	}
+
+	return nil
+}
+
+// PickJWTClaim picks a decoded JWT claim.
+func (cli *Client) PickJWTClaim(path, pickAs string) error {
+	if cli.jwt == nil {
+		return ErrNoJWT
+	}
+
+	value, err := cli.jwt.Claim(path)
+	if err != nil {
+		return err
+	}
+
+	cli.store.Pick(pickAs, value, internalPicker.DisposableValue)
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"github.com/cucumber/godog"
+
+	"github.com/elmagician/kactus/internal/api"
+	internalPicker "github.com/elmagician/kactus/internal/picker"
+)
+
+// Exposes pagination errors
+var (
+	// ErrTooManyPages is thrown when pagination still has pages after max_pages requests.
+	ErrTooManyPages = api.ErrTooManyPages
+
+	// ErrPaginationStatus is thrown when a page is not answered with a 2XX status.
+	ErrPaginationStatus = api.ErrPaginationStatus
+)
+
+// CollectPages follows pagination of a GET endpoint using current request
+// preparation and picks items of every page as a single array.
+//
+// Options are provided as a `key | value` table:
+//
+//	| key          | value                               |
+//	| mode         | link, cursor, page or offset        |
+//	| items        | items path in page body (root)      |
+//	| cursor       | next cursor path (next)             |
+//	| cursor_param | cursor query parameter (cursor)     |
+//	| page_param   | page query parameter (page)         |
+//	| start_page   | first page number (1)               |
+//	| offset_param | offset query parameter (offset)     |
+//	| limit_param  | page size query parameter (limit)   |
+//	| limit        | page size                           |
+//	| max_pages    | safety limit (100)                  |
+//
+// Default mode follows `Link: <...>; rel="next"` headers.
+func (cli *Client) CollectPages(endpoint, pickAs string, options *godog.Table) error {
+	config, err := api.OptionsFromTable(options)
+	if err != nil {
+		return err
+	}
+
+	pagination, err := api.NewPagination(config)
+	if err != nil {
+		return err
+	}
+
+	cli.SetEndpoint(endpoint)
+
+	items, err := cli.cli.Paginate(cli.request, pagination)
+	if err != nil {
+		return err
+	}
+
+	cli.store.Pick(pickAs, items, internalPicker.DisposableValue)
+
+	if cli.autoResetRequest {
+		cli.ResetRequest()
+	}
+
+	return nil
+}
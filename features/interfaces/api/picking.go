@@ -1,13 +1,40 @@
 package api
 
 import (
+	"errors"
+	"fmt"
+	"net/http"
 	"net/url"
 
 	"github.com/cucumber/godog"
 
+	"github.com/elmagician/kactus/internal"
+	"github.com/elmagician/kactus/internal/api"
 	internalPicker "github.com/elmagician/kactus/internal/picker"
 )
 
+// Sources accepted by PickFromResponse.
+const (
+	JSONSource   = "json"
+	HeaderSource = "headers"
+	CookieSource = "cookies"
+)
+
+var (
+	// ErrInvalidScope is thrown when a picking scope is neither persistent nor disposable.
+	ErrInvalidScope = errors.New("invalid picking scope")
+
+	// ErrInvalidSource is thrown when picking from an unknown response part.
+	ErrInvalidSource = errors.New("invalid picking source")
+)
+
+type pickRow struct {
+	path  string
+	as    string
+	scope internalPicker.DataScope
+	kind  string
+}
+
 // PickFromResponseJSONBody picks paths value from a response JSON body.
 func (cli *Client) PickFromResponseJSONBody(path, pickAs string) error {
 	value, err := cli.cli.Response.RetrieveJSON(path)
@@ -32,9 +59,22 @@ func (cli *Client) PickResponseHTMLTag(tag, attribute, pickAs string, filters *g
 	return nil
 }
 
-// PickResponseCookie picks cookie from response.
-func (cli *Client) PickResponseCookie(name, pickAs string) {
-	cli.store.Pick(pickAs, cli.cli.Response.GetCookie(name), internalPicker.DisposableValue)
+// PickResponseCookie picks value of a cookie set by response.
+func (cli *Client) PickResponseCookie(name, pickAs string) error {
+	return cli.PickResponseCookieAttribute(name, "value", pickAs)
+}
+
+// PickResponseCookieAttribute picks attribute of a cookie set by response:
+// value, domain, path, expires, max-age, same-site, secure or http-only.
+func (cli *Client) PickResponseCookieAttribute(name, attribute, pickAs string) error {
+	value, err := cli.cli.Response.RetrieveCookieAttribute(name, attribute)
+	if err != nil {
+		return err
+	}
+
+	cli.store.Pick(pickAs, value, internalPicker.DisposableValue)
+
+	return nil
 }
 
 // PickResponseHeader picks header from response.
@@ -42,6 +82,18 @@ func (cli *Client) PickResponseHeader(name, pickAs string) {
 	cli.store.Pick(pickAs, cli.cli.Response.RetrieveHeader(name), internalPicker.DisposableValue)
 }
 
+// PickResponseHeaderValues picks every value of a response header as an array.
+func (cli *Client) PickResponseHeaderValues(name, pickAs string) {
+	values := cli.cli.Response.HeaderValues(name)
+	picked := make([]interface{}, len(values))
+
+	for i, value := range values {
+		picked[i] = value
+	}
+
+	cli.store.Pick(pickAs, picked, internalPicker.DisposableValue)
+}
+
 // PickArgumentFromURLArg picks header from response.
 func (cli *Client) PickArgumentFromURLArg(argument, urlCandidate, pickAs string) error {
 	parsedURL, err := url.Parse(urlCandidate)
@@ -53,3 +105,103 @@ func (cli *Client) PickArgumentFromURLArg(argument, urlCandidate, pickAs string)
 
 	return nil
 }
+
+// PickFromResponse picks multiple values from response json body, headers
+// or cookies using a `path | as | scope | type` table:
+//
+//	| path     | as      | scope      | type |
+//	| user.id  | userID  | persistent | int  |
+//	| token    | token   |            |      |
+//
+// path is a json path, a header name or a cookie name depending on source.
+// Scope is disposable unless set to persistent. Optional type converts
+// picked value using type hints (int, float, bool, uuid, string).
+// Cookies value is picked.
+func (cli *Client) PickFromResponse(source string, table *godog.Table) error {
+	rows, err := parsePickTable(table)
+	if err != nil {
+		return err
+	}
+
+	values := make([]interface{}, len(rows))
+
+	for i, row := range rows {
+		var value interface{}
+
+		switch source {
+		case JSONSource:
+			if value, err = cli.cli.Response.RetrieveJSON(row.path); err != nil {
+				return err
+			}
+		case HeaderSource:
+			if _, ok := cli.cli.Response.Headers[http.CanonicalHeaderKey(row.path)]; !ok {
+				return fmt.Errorf("%w: header %s", ErrUnknownKey, row.path)
+			}
+
+			value = cli.cli.Response.RetrieveHeader(row.path)
+		case CookieSource:
+			cookie := cli.cli.Response.GetCookie(row.path)
+			if cookie == nil {
+				return fmt.Errorf("%w: %s", ErrExpectedCookie, row.path)
+			}
+
+			value = cookie.Value
+		default:
+			return fmt.Errorf("%w: %s", ErrInvalidSource, source)
+		}
+
+		if row.kind != "" {
+			if value, err = api.ConvertValue(value, row.kind); err != nil {
+				return fmt.Errorf("%s: %w", row.path, err)
+			}
+		}
+
+		values[i] = value
+	}
+
+	// every value is resolved before picking so a failing row picks nothing.
+	for i, row := range rows {
+		cli.store.Pick(row.as, values[i], row.scope)
+	}
+
+	return nil
+}
+
+func parsePickTable(table *godog.Table) ([]pickRow, error) {
+	var rows []pickRow
+
+	head := table.Rows[0].Cells
+
+	for i := 1; i < len(table.Rows); i++ {
+		row := pickRow{scope: internalPicker.DisposableValue}
+
+		for n, cell := range table.Rows[i].Cells {
+			switch head[n].Value {
+			case "path", "name":
+				row.path = cell.Value
+			case "as":
+				row.as = cell.Value
+			case "scope":
+				switch cell.Value {
+				case "", "disposable":
+				case "persistent":
+					row.scope = internalPicker.PersistentValue
+				default:
+					return nil, fmt.Errorf("%w: %s", ErrInvalidScope, cell.Value)
+				}
+			case "type":
+				row.kind = cell.Value
+			default:
+				return nil, fmt.Errorf("%w %s", internal.ErrUnexpectedColumn, head[n].Value)
+			}
+		}
+
+		if row.as == "" {
+			row.as = row.path
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
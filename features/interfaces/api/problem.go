@@ -0,0 +1,25 @@
+package api
+
+import (
+	"github.com/cucumber/godog"
+
+	"github.com/elmagician/kactus/internal/api"
+)
+
+// ErrProblem is thrown when response is not the expected problem details.
+var ErrProblem = api.ErrProblem
+
+// ResponseShouldBeAProblem asserts response is a RFC 9457 problem details:
+// application/problem+json media type, string type and title members and
+// status member equal to response status. Members, including extensions,
+// can be matched using an optional `field | matcher | value` table.
+func (cli *Client) ResponseShouldBeAProblem(expected *godog.Table) error {
+	return cli.cli.Response.IsProblem(expected)
+}
+
+// ProblemErrorsShouldContain asserts problem `errors` member lists an entry
+// for each table row, columns naming entry members, e.g. `pointer | detail`.
+// Empty cells are not checked.
+func (cli *Client) ProblemErrorsShouldContain(expected *godog.Table) error {
+	return cli.cli.Response.ProblemErrorsContain(expected)
+}
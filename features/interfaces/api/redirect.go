@@ -0,0 +1,53 @@
+package api
+
+import (
+	"github.com/cucumber/godog"
+
+	"github.com/elmagician/kactus/internal/api"
+	internalPicker "github.com/elmagician/kactus/internal/picker"
+)
+
+var (
+	// ErrRedirect is thrown when redirection chain does not match expected.
+	ErrRedirect = api.ErrRedirect
+	// ErrTooManyRedirects is thrown when following more than 10 redirections.
+	ErrTooManyRedirects = api.ErrTooManyRedirects
+)
+
+// Redirect is a redirection hop followed by client.
+type Redirect = api.Redirect
+
+// Redirects lists redirections followed to obtain last response, oldest first.
+func (cli *Client) Redirects() []Redirect {
+	return cli.cli.Response.Redirects
+}
+
+// ResponseShouldBeRedirectedTimes asserts number of redirections followed
+// to obtain last response.
+func (cli *Client) ResponseShouldBeRedirectedTimes(count int) error {
+	return cli.cli.Response.RedirectCountIs(count)
+}
+
+// RedirectChainShouldBe asserts redirections followed to obtain last response
+// using a `status | url | location | cookies` table, one row per hop.
+// URLs starting with `/` are compared with path and query only.
+func (cli *Client) RedirectChainShouldBe(expected *godog.Table) error {
+	return cli.cli.Response.RedirectChainMatches(expected)
+}
+
+// FinalURLShouldBe asserts URL which answered last response.
+func (cli *Client) FinalURLShouldBe(expected string) error {
+	return cli.cli.Response.FinalURLIs(expected)
+}
+
+// PickArgumentFromFinalURL picks a query parameter of URL which answered last response.
+func (cli *Client) PickArgumentFromFinalURL(argument, pickAs string) error {
+	value, err := cli.cli.Response.FinalURLQuery(argument)
+	if err != nil {
+		return err
+	}
+
+	cli.store.Pick(pickAs, value, internalPicker.DisposableValue)
+
+	return nil
+}
@@ -15,6 +15,21 @@ var supportedMethod = []string{"GET", "POST", "OPTION", "DELETE", "PUT", "PATCH"
 // ErrInvalidMethod is thrown when an unsupported method is provided.
 var ErrInvalidMethod = errors.New("invalid method provided")
 
+// Exposes body template errors
+var (
+	// ErrInvalidBodyPath is thrown when a body field path conflicts with existing values.
+	ErrInvalidBodyPath = api.ErrInvalidBodyPath
+
+	// ErrInvalidPatch is thrown when a JSON Patch cannot be applied.
+	ErrInvalidPatch = api.ErrInvalidPatch
+
+	// ErrPatchTest is thrown when a JSON Patch test operation fails.
+	ErrPatchTest = api.ErrPatchTest
+)
+
+// ErrInvalidSocket is thrown when an `unix://` endpoint has no socket path.
+var ErrInvalidSocket = api.ErrInvalidSocket
+
 // InitRequest starts a new request with default parameter.
 func (cli *Client) InitRequest(withCookie bool) {
 	cli.request = api.PrepareRequest(withCookie)
@@ -79,12 +94,74 @@ func (cli *Client) SetJSONBody(body *godog.DocString) {
 	cli.request = cli.request.SetJSONBody(body)
 }
 
+// SetJSONBodyFromTable replaces current request body with a JSON body
+// built from a `field | value` table. Fields are `.` separated paths
+// (`user.address.city`, `tags.0`) and values accept type hints
+// such as ((int)), ((bool)) or ((array)).
+func (cli *Client) SetJSONBodyFromTable(table *godog.Table) error {
+	cli.ClearBody()
+
+	var err error
+	cli.request, err = cli.request.SetJSONBodyFromTable(table)
+
+	return err
+}
+
+// SetJSONBodyFromFile replaces current request body with JSON template
+// loaded from file. Optional `field | value` overrides table uses the same
+// format as SetJSONBodyFromTable.
+func (cli *Client) SetJSONBodyFromFile(path string, overrides *godog.Table) error {
+	cli.ClearBody()
+
+	var err error
+	cli.request, err = cli.request.SetJSONBodyFromFile(path, overrides)
+
+	return err
+}
+
+// SetPatchedJSONBodyFromFile replaces current request body with JSON template
+// loaded from file and patched using a JSON Merge Patch (RFC 7386) or
+// a JSON Patch (RFC 6902) document depending on patchType (merge or json).
+func (cli *Client) SetPatchedJSONBodyFromFile(path, patchType string, patch *godog.DocString) error {
+	document, err := api.LoadJSONFile(path)
+	if err != nil {
+		return err
+	}
+
+	switch patchType {
+	case "merge":
+		document, err = api.MergePatch(document, patch.Content)
+	case "json":
+		document, err = api.JSONPatch(document, patch.Content)
+	default:
+		return fmt.Errorf("%w: unknown patch type %s", ErrInvalidPatch, patchType)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	cli.ClearBody()
+	cli.request, err = cli.request.SetJSONDocument(document)
+
+	return err
+}
+
 // SetFormBody replaces current request body with new Form body.
 func (cli *Client) SetFormBody(body *godog.Table) {
 	cli.ClearBody()
 	cli.request = cli.request.SetFORMBody(body)
 }
 
+// CompressBody compresses request body using algorithm (gzip, deflate, br or zstd).
+func (cli *Client) CompressBody(algorithm string) {
+	if cli.request.Empty() {
+		cli.InitRequest(true)
+	}
+
+	cli.request = cli.request.SetCompression(algorithm)
+}
+
 // SetQueryParams replaces query parameters with new ones.
 func (cli *Client) SetQueryParams(args *godog.Table) error {
 	if cli.request.Empty() {
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cucumber/godog"
+
+	"github.com/elmagician/kactus/internal/api"
+)
+
+// Built-in security header policies.
+const (
+	// DefaultSecurityPolicy is a strict policy for browser facing endpoints.
+	DefaultSecurityPolicy = "default"
+	// APISecurityPolicy only checks HSTS, nosniff and server version leaks.
+	APISecurityPolicy = "api"
+)
+
+// ErrSecurityHeaders is thrown when response headers violate a security policy.
+var ErrSecurityHeaders = api.ErrSecurityHeaders
+
+// ErrUnknownPolicy is thrown when using an undefined security policy.
+var ErrUnknownPolicy = errors.New("unknown security policy")
+
+// SecurityPolicy describes security headers expected on responses.
+type SecurityPolicy = api.SecurityPolicy
+
+// RegisterSecurityPolicy defines or replaces a named security policy.
+// Policies are kept when client is reset so suites can define them once.
+func (cli *Client) RegisterSecurityPolicy(name string, policy SecurityPolicy) {
+	cli.policies[name] = policy
+}
+
+// DefineSecurityPolicy defines a named security policy from default policy
+// overridden by a `key | value` table:
+//
+//	| key               | value                            |
+//	| hsts_max_age      | 31536000                         |
+//	| csp               | required or optional             |
+//	| csp_unsafe_inline | allow or forbid                  |
+//	| nosniff           | true or false                    |
+//	| frame_options     | DENY, SAMEORIGIN                 |
+//	| referrer_policy   | no-referrer, same-origin         |
+//	| server_version    | allow or forbid                  |
+func (cli *Client) DefineSecurityPolicy(name string, options *godog.Table) error {
+	config, err := api.OptionsFromTable(options)
+	if err != nil {
+		return err
+	}
+
+	policy, err := api.NewSecurityPolicy(config)
+	if err != nil {
+		return err
+	}
+
+	cli.RegisterSecurityPolicy(name, policy)
+
+	return nil
+}
+
+// ResponseShouldFollowSecurityPolicy asserts last response headers follow
+// named policy. Every violation is reported.
+func (cli *Client) ResponseShouldFollowSecurityPolicy(name string) error {
+	policy, ok := cli.policies[name]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownPolicy, name)
+	}
+
+	return cli.cli.Response.FollowsSecurityPolicy(policy)
+}
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/cucumber/godog"
+
+	"github.com/elmagician/kactus/internal/api"
+)
+
+// Exposes signing errors
+var (
+	// ErrMissingOption is thrown when a mandatory signing option is not provided.
+	ErrMissingOption = api.ErrMissingOption
+
+	// ErrInvalidOption is thrown when a signing option has an unexpected value.
+	ErrInvalidOption = api.ErrInvalidOption
+
+	// ErrInvalidSignature is thrown when a verifier cannot match a request signature.
+	ErrInvalidSignature = api.ErrInvalidSignature
+)
+
+// Verifier checks a received request was signed by a matching signer.
+// It allows mock servers to check webhooks emitted by tested services.
+type Verifier = api.Verifier
+
+// SignWithHMAC signs every following request with an HMAC.
+// Options are provided as a `key | value` table:
+//
+//	| key              | value                              |
+//	| secret           | {{partnerSecret}}                  |
+//	| secret_env       | PARTNER_SECRET                     |
+//	| algorithm        | sha256                             |
+//	| components       | method,path,body-hash,timestamp    |
+//	| separator        | \n                                 |
+//	| header           | X-Signature                        |
+//	| prefix           | sha256=                            |
+//	| timestamp_header | X-Timestamp                        |
+//	| encoding         | hex                                |
+//
+// Only secret (or secret_env) is mandatory.
+func (cli *Client) SignWithHMAC(options *godog.Table) error {
+	config, err := api.OptionsFromTable(options)
+	if err != nil {
+		return err
+	}
+
+	signer, err := api.NewHMACSigner(config)
+	if err != nil {
+		return err
+	}
+
+	cli.cli.SetSigner(signer)
+
+	return nil
+}
+
+// SignWithAWSV4 signs every following request with AWS Signature V4.
+// Options are provided as a `key | value` table using access_key, secret_key,
+// session_token (or their *_env variants), region and service keys.
+func (cli *Client) SignWithAWSV4(options *godog.Table) error {
+	config, err := api.OptionsFromTable(options)
+	if err != nil {
+		return err
+	}
+
+	signer, err := api.NewAWSV4Signer(config)
+	if err != nil {
+		return err
+	}
+
+	cli.cli.SetSigner(signer)
+
+	return nil
+}
+
+// DisableSigning stops signing requests.
+func (cli *Client) DisableSigning() {
+	cli.cli.SetSigner(nil)
+}
+
+// NewHMACVerifier initializes a Verifier matching requests signed by SignWithHMAC
+// using the same options.
+func NewHMACVerifier(options map[string]string) (Verifier, error) {
+	return api.NewHMACSigner(options)
+}
+
+// NewAWSV4Verifier initializes a Verifier matching requests signed by SignWithAWSV4
+// using the same options.
+func NewAWSV4Verifier(options map[string]string) (Verifier, error) {
+	return api.NewAWSV4Signer(options)
+}
+
+// VerifySignature wraps an http.Handler to reject requests with an invalid
+// signature using a 401 Unauthorized status.
+func VerifySignature(verifier Verifier, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if err := verifier.Verify(req); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, req)
+	})
+}
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/elmagician/kactus/internal/api"
+)
+
+// ErrTimeout is thrown when a request is not answered within its timeout.
+var ErrTimeout = api.ErrTimeout
+
+// ErrNoTimeout is thrown when a request expected to time out is answered.
+var ErrNoTimeout = errors.New("request did not time out")
+
+// SetDefaultTimeout sets timeout applied to every request without its own
+// timeout. It is kept when client is reset so suites can define it once.
+// Zero disables it.
+func (cli *Client) SetDefaultTimeout(timeout time.Duration) {
+	cli.cli.SetDefaultTimeout(timeout)
+}
+
+// SetDefaultTimeoutSeconds sets default request timeout in seconds.
+func (cli *Client) SetDefaultTimeoutSeconds(seconds float64) {
+	cli.SetDefaultTimeout(secondsToDuration(seconds))
+}
+
+// SetRequestTimeout sets current request timeout in seconds.
+func (cli *Client) SetRequestTimeout(seconds float64) {
+	if cli.request.Empty() {
+		cli.InitRequest(true)
+	}
+
+	cli.request = cli.request.SetTimeout(secondsToDuration(seconds))
+}
+
+// RequestShouldTimeOutWithin executes current request with a timeout
+// and asserts it is not answered before timeout.
+func (cli *Client) RequestShouldTimeOutWithin(seconds float64) error {
+	cli.SetRequestTimeout(seconds)
+
+	err := cli.ExecuteRequest()
+	if cli.autoResetRequest {
+		cli.ResetRequest()
+	}
+
+	switch {
+	case errors.Is(err, ErrTimeout):
+		return nil
+	case err != nil:
+		return err
+	default:
+		return fmt.Errorf("%w: answered with status %d", ErrNoTimeout, cli.cli.Response.Status)
+	}
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
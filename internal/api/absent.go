@@ -0,0 +1,88 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// wildcard matches any object key or array index in a path.
+const wildcard = "*"
+
+// ErrUnexpectedPath is thrown when a path expected to be absent exists.
+var ErrUnexpectedPath = errors.New("path should not exist")
+
+// JSONPathsAbsent asserts none of paths exist in response JSON body.
+// Paths are `.` separated and accept `*` wildcards matching any object key
+// or array index, e.g. `users.*.password`. A key holding null exists.
+func (r Response) JSONPathsAbsent(paths []string) error {
+	if err := r.bodyError(); err != nil {
+		return err
+	}
+
+	document, err := r.Decode()
+	if err != nil {
+		return err
+	}
+
+	var found []string
+
+	for _, path := range paths {
+		found = append(found, FindPaths(document, path)...)
+	}
+
+	if len(found) > 0 {
+		sort.Strings(found)
+
+		return fmt.Errorf("%w: %s", ErrUnexpectedPath, strings.Join(found, ", "))
+	}
+
+	return nil
+}
+
+// FindPaths returns existing concrete paths matching a `.` separated path
+// which may contain `*` wildcards.
+func FindPaths(document interface{}, path string) []string {
+	return findPaths(document, strings.Split(path, "."), "")
+}
+
+func findPaths(node interface{}, keys []string, prefix string) []string {
+	if len(keys) == 0 {
+		return []string{prefix}
+	}
+
+	var found []string
+
+	key, rest := keys[0], keys[1:]
+
+	switch current := node.(type) {
+	case map[string]interface{}:
+		if key == wildcard {
+			for name, child := range current {
+				found = append(found, findPaths(child, rest, joinPath(prefix, name))...)
+			}
+
+			return found
+		}
+
+		if child, ok := current[key]; ok {
+			return findPaths(child, rest, joinPath(prefix, key))
+		}
+	case []interface{}:
+		if key == wildcard {
+			for i, child := range current {
+				found = append(found, findPaths(child, rest, joinPath(prefix, strconv.Itoa(i)))...)
+			}
+
+			return found
+		}
+
+		if index, err := strconv.Atoi(key); err == nil && index >= 0 && index < len(current) {
+			return findPaths(current[index], rest, joinPath(prefix, key))
+		}
+	}
+
+	return found
+}
@@ -0,0 +1,40 @@
+package api_test
+
+import (
+	"net/http"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/elmagician/kactus/internal/api"
+	. "github.com/elmagician/kactus/internal/test"
+)
+
+func TestUnit_Response_JSONPathsAbsent(t *testing.T) {
+	Convey("Given a JSON response", t, func() {
+		response := api.NewResponse(http.StatusOK, []byte(`{
+			"users": [
+				{"name": "a", "email": "a@example.com"},
+				{"name": "b", "password": "hash", "meta": {"internalId": null}}
+			],
+			"owner": {"name": "c"}
+		}`), nil, http.Header{})
+
+		Convey("should pass when paths are absent", func() {
+			So(response.JSONPathsAbsent([]string{"password", "users.*.token", "users.5", "owner.name.first"}), ShouldBeNil)
+		})
+
+		Convey("should report every matching path", func() {
+			err := response.JSONPathsAbsent([]string{"users.*.password", "*.*.meta.internalId"})
+
+			So(err, ShouldBeLikeError, api.ErrUnexpectedPath)
+			So(err.Error(), ShouldContainSubstring, "users.1.meta.internalId, users.1.password")
+		})
+
+		Convey("should find concrete paths", func() {
+			So(api.FindPaths(map[string]interface{}{"a": map[string]interface{}{"x": 1, "y": 2}}, "a.*"),
+				ShouldHaveLength, 2)
+			So(api.FindPaths([]interface{}{"a"}, "0"), ShouldResemble, []string{"0"})
+		})
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"reflect"
 	"strings"
 
 	"github.com/cucumber/godog"
@@ -38,17 +39,17 @@ func (r Response) HasEmptyBody() bool {
 
 func (r Response) JSONContains(fully bool, expected *godog.Table) error {
 	var (
-		actual               interface{}
 		actualFullPaths      []string
 		expectedPaths        []string
 		path, value, matcher string
 	)
 
-	if r.HasEmptyBody() {
-		return ErrNoBody
+	if err := r.bodyError(); err != nil {
+		return err
 	}
 
-	if err := json.Unmarshal(r.Body, &actual); err != nil {
+	actual, err := r.Decode()
+	if err != nil {
 		return err
 	}
 
@@ -109,6 +110,49 @@ func (r Response) JSONContains(fully bool, expected *godog.Table) error {
 	return nil
 }
 
+// FieldsMatch asserts actual object matches a `field | matcher | value` table.
+// Fields are provided as `.` separated paths. An empty field or `.` targets
+// actual object itself.
+func FieldsMatch(actual interface{}, expected *godog.Table) error {
+	var path, value, matcher string
+
+	head := expected.Rows[0].Cells
+
+	for i := 1; i < len(expected.Rows); i++ {
+		for n, cell := range expected.Rows[i].Cells {
+			switch head[n].Value {
+			case fieldHeader:
+				path = cell.Value
+			case matcherHeader:
+				matcher = cell.Value
+			case valueHeader:
+				value = cell.Value
+			default:
+				return fmt.Errorf("%w %s", internal.ErrUnexpectedColumn, head[n].Value)
+			}
+		}
+
+		actualVal, exists := reflect.ValueOf(actual), true
+		if path != "" && path != "." {
+			actualVal, exists = interfaces.GetFieldFromPath(actual, path)
+		}
+
+		if !exists {
+			return fmt.Errorf("%w: %v", ErrUnknownKey, path)
+		}
+
+		if err := match.Assert(matcher, actualVal, value); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+
+		path = ""
+		value = ""
+		matcher = ""
+	}
+
+	return nil
+}
+
 func (r Response) JSONResemble(expectedBody *godog.DocString) error {
 	var expected, actual interface{}
 	var err error
@@ -119,7 +163,7 @@ func (r Response) JSONResemble(expectedBody *godog.DocString) error {
 	}
 
 	// re-encode actual response too
-	if err = json.Unmarshal(r.Body, &actual); err != nil {
+	if actual, err = r.Decode(); err != nil {
 		return err
 	}
 
@@ -132,6 +176,10 @@ func (r Response) JSONResemble(expectedBody *godog.DocString) error {
 }
 
 func (r Response) HTMLContain(expectedBody *godog.Table) error {
+	if err := r.streamedError(); err != nil {
+		return err
+	}
+
 	actual := string(r.Body)
 
 	for _, row := range expectedBody.Rows {
@@ -148,6 +196,10 @@ func (r Response) HTMLContain(expectedBody *godog.Table) error {
 }
 
 func (r Response) HTMLResemble(expectedBody *godog.DocString) error {
+	if err := r.streamedError(); err != nil {
+		return err
+	}
+
 	var expected, actual interface{}
 
 	expected = expectedBody.Content
@@ -161,6 +213,8 @@ func (r Response) HTMLResemble(expectedBody *godog.DocString) error {
 	return nil
 }
 
+// HeaderMatches asserts response headers match a `key | matcher | value` table.
+// A header matches when any of its values matches.
 func (r Response) HeaderMatches(expected *godog.Table) error {
 	var (
 		key, value, matcher string
@@ -171,7 +225,7 @@ func (r Response) HeaderMatches(expected *godog.Table) error {
 	for i := 1; i < len(expected.Rows); i++ {
 		for n, cell := range expected.Rows[i].Cells {
 			switch head[n].Value {
-			case "key":
+			case "key", "header":
 				key = cell.Value
 			case matcherHeader:
 				matcher = cell.Value
@@ -182,12 +236,12 @@ func (r Response) HeaderMatches(expected *godog.Table) error {
 			}
 		}
 
-		if actualVal := r.Headers.Get(key); actualVal != "" {
-			if err := match.Assert(matcher, actualVal, value); err != nil {
-				return err
-			}
-		} else {
-			return fmt.Errorf("%w: %v", ErrNoMatch, key)
+		if !r.HasHeader(key) {
+			return fmt.Errorf("%w: %w %v", ErrNoMatch, ErrMissingHeader, key)
+		}
+
+		if err := r.HeaderValuesMatch(key, AnyValue, matcher, value); err != nil {
+			return err
 		}
 
 		key = ""
@@ -1,12 +1,11 @@
 package api_test
 
 import (
-	"errors"
 	"net/http"
 	"testing"
 
 	"github.com/cucumber/godog"
-	"github.com/cucumber/messages-go/v10"
+	messages "github.com/cucumber/messages/go/v21"
 	. "github.com/smartystreets/goconvey/convey"
 
 	"github.com/elmagician/kactus/internal"
@@ -35,28 +34,28 @@ func TestUnit_Response_JSONContains(t *testing.T) {
 		}
 
 		Convey("I should be able to match partially response JSON body", func() {
-			partialDataTable := &messages.PickleStepArgument_PickleTable{
-				Rows: []*messages.PickleStepArgument_PickleTable_PickleTableRow{
+			partialDataTable := &messages.PickleTable{
+				Rows: []*messages.PickleTableRow{
 					{
-						Cells: []*messages.PickleStepArgument_PickleTable_PickleTableRow_PickleTableCell{
+						Cells: []*messages.PickleTableCell{
 							{Value: "field"},
 							{Value: "matcher"},
 							{Value: "value"},
 						},
 					}, {
-						Cells: []*messages.PickleStepArgument_PickleTable_PickleTableRow_PickleTableCell{
+						Cells: []*messages.PickleTableCell{
 							{Value: "0"},
 							{Value: "not zero"},
 							{Value: ""},
 						},
 					}, {
-						Cells: []*messages.PickleStepArgument_PickleTable_PickleTableRow_PickleTableCell{
+						Cells: []*messages.PickleTableCell{
 							{Value: "1.name"},
 							{Value: "eq"},
 							{Value: "fred"},
 						},
 					}, {
-						Cells: []*messages.PickleStepArgument_PickleTable_PickleTableRow_PickleTableCell{
+						Cells: []*messages.PickleTableCell{
 							{Value: "2.class.strong"},
 							{Value: "eq"},
 							{Value: "learner"},
@@ -80,22 +79,22 @@ func TestUnit_Response_JSONContains(t *testing.T) {
 ]`
 			r.Body = []byte(jsonBody)
 
-			partialDataTable := &messages.PickleStepArgument_PickleTable{
-				Rows: []*messages.PickleStepArgument_PickleTable_PickleTableRow{
+			partialDataTable := &messages.PickleTable{
+				Rows: []*messages.PickleTableRow{
 					{
-						Cells: []*messages.PickleStepArgument_PickleTable_PickleTableRow_PickleTableCell{
+						Cells: []*messages.PickleTableCell{
 							{Value: "field"},
 							{Value: "matcher"},
 							{Value: "value"},
 						},
 					}, {
-						Cells: []*messages.PickleStepArgument_PickleTable_PickleTableRow_PickleTableCell{
+						Cells: []*messages.PickleTableCell{
 							{Value: "0.name"},
 							{Value: "eq"},
 							{Value: "fred"},
 						},
 					}, {
-						Cells: []*messages.PickleStepArgument_PickleTable_PickleTableRow_PickleTableCell{
+						Cells: []*messages.PickleTableCell{
 							{Value: "1.class.strong"},
 							{Value: "eq"},
 							{Value: "learner"},
@@ -108,28 +107,28 @@ func TestUnit_Response_JSONContains(t *testing.T) {
 		})
 
 		Convey("I should have an error if JSON response body does not fully match expected body (too much fields)", func() {
-			partialDataTable := &messages.PickleStepArgument_PickleTable{
-				Rows: []*messages.PickleStepArgument_PickleTable_PickleTableRow{
+			partialDataTable := &messages.PickleTable{
+				Rows: []*messages.PickleTableRow{
 					{
-						Cells: []*messages.PickleStepArgument_PickleTable_PickleTableRow_PickleTableCell{
+						Cells: []*messages.PickleTableCell{
 							{Value: "field"},
 							{Value: "matcher"},
 							{Value: "value"},
 						},
 					}, {
-						Cells: []*messages.PickleStepArgument_PickleTable_PickleTableRow_PickleTableCell{
+						Cells: []*messages.PickleTableCell{
 							{Value: "0"},
 							{Value: "not zero"},
 							{Value: ""},
 						},
 					}, {
-						Cells: []*messages.PickleStepArgument_PickleTable_PickleTableRow_PickleTableCell{
+						Cells: []*messages.PickleTableCell{
 							{Value: "1.name"},
 							{Value: "eq"},
 							{Value: "fred"},
 						},
 					}, {
-						Cells: []*messages.PickleStepArgument_PickleTable_PickleTableRow_PickleTableCell{
+						Cells: []*messages.PickleTableCell{
 							{Value: "2.class.strong"},
 							{Value: "eq"},
 							{Value: "learner"},
@@ -138,7 +137,7 @@ func TestUnit_Response_JSONContains(t *testing.T) {
 				},
 			}
 
-			So(errors.Is(api.ErrNotFullyMatch, r.JSONContains(true, partialDataTable)), ShouldBeTrue)
+			So(r.JSONContains(true, partialDataTable), ShouldBeLikeError, api.ErrNotFullyMatch)
 		})
 	})
 }
@@ -201,15 +200,15 @@ func TestUnit_Response_HTMLContain(t *testing.T) {
 		value2 := "another HTML"
 		r := api.Response{Body: []byte(value1 + value2)}
 		expectedBody := &godog.Table{
-			Rows: []*messages.PickleStepArgument_PickleTable_PickleTableRow{
+			Rows: []*messages.PickleTableRow{
 				{
-					Cells: []*messages.PickleStepArgument_PickleTable_PickleTableRow_PickleTableCell{
+					Cells: []*messages.PickleTableCell{
 						{Value: value1},
 						{Value: value2},
 					},
 				},
 				{
-					Cells: []*messages.PickleStepArgument_PickleTable_PickleTableRow_PickleTableCell{
+					Cells: []*messages.PickleTableCell{
 						{Value: value2},
 					},
 				},
@@ -263,21 +262,21 @@ func TestUnit_Response_HeaderMatches(t *testing.T) {
 		}
 
 		expectedHeaders := &godog.Table{
-			Rows: []*messages.PickleStepArgument_PickleTable_PickleTableRow{
+			Rows: []*messages.PickleTableRow{
 				{
-					Cells: []*messages.PickleStepArgument_PickleTable_PickleTableRow_PickleTableCell{
+					Cells: []*messages.PickleTableCell{
 						{Value: "key"},
 						{Value: "matcher"},
 						{Value: "value"},
 					},
 				}, {
-					Cells: []*messages.PickleStepArgument_PickleTable_PickleTableRow_PickleTableCell{
+					Cells: []*messages.PickleTableCell{
 						{Value: header1Key},
 						{Value: "eq"},
 						{Value: header1Value},
 					},
 				}, {
-					Cells: []*messages.PickleStepArgument_PickleTable_PickleTableRow_PickleTableCell{
+					Cells: []*messages.PickleTableCell{
 						{Value: header2Key},
 						{Value: "eq"},
 						{Value: header2Value},
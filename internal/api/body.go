@@ -0,0 +1,166 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cucumber/godog"
+
+	"github.com/elmagician/kactus/internal"
+	"github.com/elmagician/kactus/internal/types"
+)
+
+// ErrInvalidBodyPath is thrown when a body path conflicts with existing values.
+var ErrInvalidBodyPath = errors.New("invalid body path")
+
+// SetJSONBodyFromTable builds a JSON body from a `field | value` table.
+// See ApplyTable for table format.
+func (request RequestPreparation) SetJSONBodyFromTable(table *godog.Table) (RequestPreparation, error) {
+	document, err := ApplyTable(nil, table)
+	if err != nil {
+		return request, err
+	}
+
+	return request.SetJSONDocument(document)
+}
+
+// SetJSONBodyFromFile loads a JSON body template from file, applies
+// `field | value` table overrides (see ApplyTable) and sets it as request body.
+func (request RequestPreparation) SetJSONBodyFromFile(path string, overrides *godog.Table) (RequestPreparation, error) {
+	document, err := LoadJSONFile(path)
+	if err != nil {
+		return request, err
+	}
+
+	if document, err = ApplyTable(document, overrides); err != nil {
+		return request, err
+	}
+
+	return request.SetJSONDocument(document)
+}
+
+// SetJSONDocument encodes a decoded JSON document as request body.
+func (request RequestPreparation) SetJSONDocument(document interface{}) (RequestPreparation, error) {
+	raw, err := json.Marshal(document)
+	if err != nil {
+		return request, err
+	}
+
+	content := string(raw)
+	request.JSONBody = &content
+
+	return request, nil
+}
+
+// ApplyTable sets values of a `field | value` table in document and returns
+// updated document. Fields are `.` separated paths where numeric elements are
+// array indexes (`user.address.city`, `tags.0`). Missing objects and arrays
+// are created. Values are converted using types.ToInterface type hints:
+//
+//	| field      | value           |
+//	| user.name  | kactus          |
+//	| user.age   | 3((int))        |
+//	| active     | true((bool))    |
+//	| tags       | a,b((array))    |
+func ApplyTable(document interface{}, table *godog.Table) (interface{}, error) {
+	var path, raw string
+
+	if table == nil || len(table.Rows) == 0 {
+		return document, nil
+	}
+
+	head := table.Rows[0].Cells
+
+	for i := 1; i < len(table.Rows); i++ {
+		for n, cell := range table.Rows[i].Cells {
+			switch head[n].Value {
+			case fieldHeader:
+				path = cell.Value
+			case valueHeader:
+				raw = cell.Value
+			default:
+				return nil, fmt.Errorf("%w %s", internal.ErrUnexpectedColumn, head[n].Value)
+			}
+		}
+
+		value, err := types.ToInterface(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+
+		if document, err = SetPath(document, path, value); err != nil {
+			return nil, err
+		}
+
+		path = ""
+		raw = ""
+	}
+
+	return document, nil
+}
+
+// SetPath sets value under a `.` separated path in a decoded JSON document,
+// creating missing objects and arrays, and returns updated document.
+func SetPath(document interface{}, path string, value interface{}) (interface{}, error) {
+	if path == "" || path == "." {
+		return value, nil
+	}
+
+	return setPath(document, strings.Split(path, "."), path, value)
+}
+
+func setPath(node interface{}, keys []string, path string, value interface{}) (interface{}, error) {
+	if len(keys) == 0 {
+		return value, nil
+	}
+
+	key := keys[0]
+
+	if index, err := strconv.Atoi(key); err == nil && index >= 0 {
+		var items []interface{}
+
+		switch current := node.(type) {
+		case nil:
+		case []interface{}:
+			items = current
+		default:
+			return nil, fmt.Errorf("%w: %s is not an array", ErrInvalidBodyPath, path)
+		}
+
+		for len(items) <= index {
+			items = append(items, nil)
+		}
+
+		child, err := setPath(items[index], keys[1:], path, value)
+		if err != nil {
+			return nil, err
+		}
+
+		items[index] = child
+
+		return items, nil
+	}
+
+	var object map[string]interface{}
+
+	switch current := node.(type) {
+	case nil:
+		object = make(map[string]interface{})
+	case map[string]interface{}:
+		object = current
+	default:
+		return nil, fmt.Errorf("%w: %s is not an object", ErrInvalidBodyPath, path)
+	}
+
+	child, err := setPath(object[key], keys[1:], path, value)
+	if err != nil {
+		return nil, err
+	}
+
+	object[key] = child
+
+	return object, nil
+}
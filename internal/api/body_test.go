@@ -0,0 +1,55 @@
+package api_test
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/elmagician/kactus/internal/api"
+	. "github.com/elmagician/kactus/internal/test"
+)
+
+func TestUnit_RequestPreparation_SetJSONBodyFromTable(t *testing.T) {
+	Convey("When I build a JSON body from a table", t, func() {
+		Convey("should create nested typed values", func() {
+			req, err := api.PrepareRequest(false).SetJSONBodyFromTable(NewTable(
+				[]string{"field", "value"},
+				[]string{"user.name", "kactus"},
+				[]string{"user.age", "3((int))"},
+				[]string{"user.address.city", "Paris"},
+				[]string{"active", "true((bool))"},
+				[]string{"ratio", "0.5((float))"},
+				[]string{"tags.1", "b"},
+				[]string{"tags.0", "a"},
+				[]string{"roles", "admin,user((array))"},
+				[]string{"empty", "((array))"},
+			))
+
+			So(err, ShouldBeNil)
+			So(*req.JSONBody, ShouldEqualJSON, `{
+				"user": {"name": "kactus", "age": 3, "address": {"city": "Paris"}},
+				"active": true, "ratio": 0.5,
+				"tags": ["a", "b"], "roles": ["admin", "user"], "empty": []
+			}`)
+		})
+
+		Convey("should reject conflicting paths", func() {
+			_, err := api.PrepareRequest(false).SetJSONBodyFromTable(NewTable(
+				[]string{"field", "value"},
+				[]string{"user", "kactus"},
+				[]string{"user.name", "kactus"},
+			))
+
+			So(err, ShouldBeLikeError, api.ErrInvalidBodyPath)
+		})
+
+		Convey("should reject invalid type hints", func() {
+			_, err := api.PrepareRequest(false).SetJSONBodyFromTable(NewTable(
+				[]string{"field", "value"},
+				[]string{"age", "three((int))"},
+			))
+
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
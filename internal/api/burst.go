@@ -0,0 +1,202 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrInvalidBurst is thrown when burst parameters are not valid.
+	ErrInvalidBurst = errors.New("invalid burst parameters")
+	// ErrNoBurst is thrown when asserting on burst results before sending one.
+	ErrNoBurst = errors.New("no burst sent")
+)
+
+type (
+	// BurstResult is the outcome of a single burst request.
+	BurstResult struct {
+		Status  int
+		Latency time.Duration
+		Err     error
+	}
+
+	// BurstReport aggregates every burst request outcome.
+	BurstReport struct {
+		Results  []BurstResult
+		Duration time.Duration
+	}
+)
+
+// Burst emits count copies of the prepared request using workers concurrent
+// workers. Requests are generated and signed before being sent so that every
+// worker only measures the round trip.
+//
+// Client Response is not updated.
+func (cli *Client) Burst(req RequestPreparation, count, workers int) (*BurstReport, error) {
+	if req.Empty() {
+		return nil, ErrNoRequest
+	}
+
+	if count < 1 || workers < 1 {
+		return nil, fmt.Errorf("%w: count and workers should be positive", ErrInvalidBurst)
+	}
+
+	requests := make([]*http.Request, count)
+
+	for i := range requests {
+		generated, err := cli.generateRequest(req)
+		if err != nil {
+			return nil, err
+		}
+
+		generated.Header = generated.Header.Clone()
+
+		if cli.signer != nil {
+			if err = cli.signer.Sign(generated); err != nil {
+				return nil, err
+			}
+		}
+
+		requests[i] = generated
+	}
+
+	timeout := cli.timeoutFor(req)
+	report := &BurstReport{Results: make([]BurstResult, count)}
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+
+	start := time.Now()
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range jobs {
+				report.Results[i] = cli.timedDo(requests[i], timeout)
+			}
+		}()
+	}
+
+	for i := range requests {
+		jobs <- i
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	report.Duration = time.Since(start)
+
+	log.Debug("burst done", zap.Int("count", count), zap.Int("workers", workers), zap.Duration("duration", report.Duration))
+
+	return report, nil
+}
+
+func (cli *Client) timedDo(req *http.Request, timeout time.Duration) BurstResult {
+	if timeout > 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+
+		req = req.WithContext(ctx)
+	}
+
+	start := time.Now()
+
+	resp, err := cli.client.Do(req)
+	if err != nil {
+		return BurstResult{Latency: time.Since(start), Err: timeoutError(err, timeout)}
+	}
+
+	_, copyErr := io.Copy(ioutil.Discard, resp.Body)
+	closeErr := resp.Body.Close()
+
+	if copyErr == nil {
+		copyErr = closeErr
+	}
+
+	if copyErr != nil {
+		copyErr = timeoutError(copyErr, timeout)
+	}
+
+	return BurstResult{Status: resp.StatusCode, Latency: time.Since(start), Err: copyErr}
+}
+
+// StatusCount returns number of requests answered with status.
+func (report BurstReport) StatusCount(status int) int {
+	count := 0
+
+	for _, result := range report.Results {
+		if result.Err == nil && result.Status == status {
+			count++
+		}
+	}
+
+	return count
+}
+
+// StatusDistribution returns number of requests per answered status.
+func (report BurstReport) StatusDistribution() map[int]int {
+	distribution := make(map[int]int)
+
+	for _, result := range report.Results {
+		if result.Err == nil {
+			distribution[result.Status]++
+		}
+	}
+
+	return distribution
+}
+
+// Errors returns requests which failed without response.
+func (report BurstReport) Errors() []error {
+	var errs []error
+
+	for _, result := range report.Results {
+		if result.Err != nil {
+			errs = append(errs, result.Err)
+		}
+	}
+
+	return errs
+}
+
+// Percentile returns latency percentile (0-100) of answered requests using
+// nearest rank method. Failed requests, e.g. timeouts, are ignored.
+func (report BurstReport) Percentile(percentile float64) time.Duration {
+	latencies := make([]time.Duration, 0, len(report.Results))
+
+	for _, result := range report.Results {
+		if result.Err == nil {
+			latencies = append(latencies, result.Latency)
+		}
+	}
+
+	if len(latencies) == 0 {
+		return 0
+	}
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+
+	rank := int(math.Ceil(percentile / 100 * float64(len(latencies)))) // nolint: gomnd
+	if rank < 1 {
+		rank = 1
+	}
+
+	if rank > len(latencies) {
+		rank = len(latencies)
+	}
+
+	return latencies[rank-1]
+}
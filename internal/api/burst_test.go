@@ -0,0 +1,78 @@
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/elmagician/kactus/internal/api"
+	. "github.com/elmagician/kactus/internal/test"
+)
+
+func TestUnit_Client_Burst(t *testing.T) {
+	Convey("Given a rate limited endpoint", t, func() {
+		var calls int32
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) > 5 {
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		cli, err := api.NewClient(&http.Client{})
+		So(err, ShouldBeNil)
+
+		req := api.PrepareRequest(false).SetEndpoint(server.URL)
+
+		Convey("burst should collect every status", func() {
+			report, err := cli.Burst(req, 20, 4)
+
+			So(err, ShouldBeNil)
+			So(report.Results, ShouldHaveLength, 20)
+			So(report.Errors(), ShouldBeEmpty)
+			So(report.StatusCount(http.StatusOK), ShouldEqual, 5)
+			So(report.StatusDistribution(), ShouldResemble, map[int]int{http.StatusOK: 5, http.StatusTooManyRequests: 15})
+		})
+
+		Convey("burst should reject invalid parameters", func() {
+			_, err := cli.Burst(req, 0, 1)
+			So(err, ShouldBeLikeError, api.ErrInvalidBurst)
+
+			_, err = cli.Burst(api.RequestPreparation{}, 1, 1)
+			So(err, ShouldBeError, api.ErrNoRequest)
+		})
+	})
+}
+
+func TestUnit_BurstReport_Percentile(t *testing.T) {
+	Convey("When I compute burst latency percentiles", t, func() {
+		report := api.BurstReport{}
+
+		So(report.Percentile(50), ShouldEqual, 0)
+
+		for i := 10; i >= 1; i-- {
+			report.Results = append(report.Results, api.BurstResult{Latency: time.Duration(i) * time.Millisecond})
+		}
+
+		So(report.Percentile(50), ShouldEqual, 5*time.Millisecond)
+		So(report.Percentile(95), ShouldEqual, 10*time.Millisecond)
+		So(report.Percentile(10), ShouldEqual, time.Millisecond)
+		So(report.Percentile(0), ShouldEqual, time.Millisecond)
+
+		Convey("failed requests should be ignored", func() {
+			report.Results = append(report.Results, api.BurstResult{Latency: time.Second, Err: context.DeadlineExceeded})
+
+			So(report.Percentile(99), ShouldEqual, 10*time.Millisecond)
+			So(api.BurstReport{Results: report.Results[10:]}.Percentile(50), ShouldEqual, 0)
+		})
+	})
+}
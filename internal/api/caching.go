@@ -0,0 +1,193 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Conditional request headers.
+const (
+	IfNoneMatch       = "If-None-Match"
+	IfMatch           = "If-Match"
+	IfModifiedSince   = "If-Modified-Since"
+	IfUnmodifiedSince = "If-Unmodified-Since"
+)
+
+var (
+	// ErrNoValidator is thrown when no ETag or Last-Modified validator is available.
+	ErrNoValidator = errors.New("no cache validator")
+	// ErrConditional is thrown when a conditional request is not answered as expected.
+	ErrConditional = errors.New("conditional request not answered as expected")
+	// ErrCacheControl is thrown when Cache-Control does not match expected.
+	ErrCacheControl = errors.New("cache control does not match expected")
+)
+
+// Validators are cache validators of a response.
+type Validators struct {
+	ETag         string
+	LastModified string
+}
+
+// Validators retrieves response ETag and Last-Modified.
+func (r Response) Validators() Validators {
+	return Validators{ETag: r.Headers.Get("ETag"), LastModified: r.Headers.Get("Last-Modified")}
+}
+
+// Empty is true when no validator is provided.
+func (v Validators) Empty() bool {
+	return v.ETag == "" && v.LastModified == ""
+}
+
+// Condition returns value of a conditional header: ETag for If-None-Match
+// and If-Match, Last-Modified for If-Modified-Since and If-Unmodified-Since.
+func (v Validators) Condition(header string) (string, error) {
+	var value string
+
+	switch http.CanonicalHeaderKey(header) {
+	case IfNoneMatch, IfMatch:
+		value = v.ETag
+	case IfModifiedSince, IfUnmodifiedSince:
+		value = v.LastModified
+	default:
+		return "", fmt.Errorf("%w: unknown conditional header %s", ErrInvalidOption, header)
+	}
+
+	if value == "" {
+		return "", fmt.Errorf("%w for %s", ErrNoValidator, header)
+	}
+
+	return value, nil
+}
+
+// CaptureValidators stores last response validators to build conditional requests.
+func (cli *Client) CaptureValidators() (Validators, error) {
+	if cli.Response == nil {
+		return Validators{}, ErrNoValidator
+	}
+
+	validators := cli.Response.Validators()
+	if validators.Empty() {
+		return validators, fmt.Errorf("%w: response has no ETag nor Last-Modified", ErrNoValidator)
+	}
+
+	cli.validators = validators
+
+	return validators, nil
+}
+
+// CapturedValidators returns validators stored by CaptureValidators.
+func (cli *Client) CapturedValidators() Validators {
+	return cli.validators
+}
+
+// SetCondition sets a conditional header on req using captured validators.
+func (cli *Client) SetCondition(req RequestPreparation, header string) (RequestPreparation, error) {
+	value, err := cli.validators.Condition(header)
+	if err != nil {
+		return req, err
+	}
+
+	headers := http.Header{}
+	if req.Headers != nil {
+		headers = req.Headers.Clone()
+	}
+
+	headers.Set(header, value)
+	req.Headers = &headers
+
+	return req, nil
+}
+
+// Replay emits last emitted request again with a conditional header
+// built from captured validators.
+func (cli *Client) Replay(header string) error {
+	if cli.last.Empty() {
+		return ErrNoRequest
+	}
+
+	req, err := cli.SetCondition(cli.last, header)
+	if err != nil {
+		return err
+	}
+
+	req.Arguments = copyArguments(req.Arguments)
+
+	return cli.EmitRequest(req)
+}
+
+// IsNotModified asserts response is a 304 Not Modified without body.
+// When validators were captured, ETag must be unchanged.
+func (r Response) IsNotModified(captured Validators) error {
+	if r.Status != http.StatusNotModified {
+		return fmt.Errorf("%w: expected status %d, got %d", ErrConditional, http.StatusNotModified, r.Status)
+	}
+
+	if !r.HasEmptyBody() {
+		return fmt.Errorf("%w: 304 response should not have a body", ErrConditional)
+	}
+
+	if etag := r.Headers.Get("ETag"); etag != "" && captured.ETag != "" && etag != captured.ETag {
+		return fmt.Errorf("%w: ETag changed from %s to %s", ErrConditional, captured.ETag, etag)
+	}
+
+	return nil
+}
+
+// CacheControl parses Cache-Control directives. Names are lower cased and
+// directives without value are mapped to an empty string.
+func (r Response) CacheControl() map[string]string {
+	directives := make(map[string]string)
+
+	for _, line := range r.Headers.Values("Cache-Control") {
+		for _, directive := range strings.Split(line, ",") {
+			name, value, _ := strings.Cut(strings.TrimSpace(directive), "=")
+			if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+				directives[name] = strings.Trim(strings.TrimSpace(value), `"`)
+			}
+		}
+	}
+
+	return directives
+}
+
+// CacheControlHas asserts Cache-Control has, or has not when not is true, directive.
+func (r Response) CacheControlHas(not bool, directive string) error {
+	_, has := r.CacheControl()[strings.ToLower(directive)]
+
+	switch {
+	case has && not:
+		return fmt.Errorf("%w: %q should not have %s", ErrCacheControl, r.Headers.Get("Cache-Control"), directive)
+	case !has && !not:
+		return fmt.Errorf("%w: %q should have %s", ErrCacheControl, r.Headers.Get("Cache-Control"), directive)
+	}
+
+	return nil
+}
+
+// CacheControlAgeBetween asserts a delta seconds directive such as max-age
+// or s-maxage is within [minimum, maximum]. A negative maximum is unbounded.
+func (r Response) CacheControlAgeBetween(directive string, minimum, maximum int) error {
+	raw, has := r.CacheControl()[strings.ToLower(directive)]
+	if !has {
+		return fmt.Errorf("%w: %q should have %s", ErrCacheControl, r.Headers.Get("Cache-Control"), directive)
+	}
+
+	age, err := strconv.Atoi(raw)
+	if err != nil {
+		return fmt.Errorf("%w: invalid %s=%s", ErrCacheControl, directive, raw)
+	}
+
+	if age < minimum || (maximum >= 0 && age > maximum) {
+		bound := "+inf"
+		if maximum >= 0 {
+			bound = strconv.Itoa(maximum)
+		}
+
+		return fmt.Errorf("%w: %s=%d not in [%d, %s]", ErrCacheControl, directive, age, minimum, bound)
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/elmagician/kactus/internal/api"
+	. "github.com/elmagician/kactus/internal/test"
+)
+
+func TestUnit_Client_ConditionalRequests(t *testing.T) {
+	Convey("Given a resource with validators", t, func() {
+		etag := `"v1"`
+		lastModified := "Wed, 21 Oct 2015 07:28:00 GMT"
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if match := r.Header.Get(api.IfMatch); match != "" && match != etag {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				return
+			}
+
+			if r.Method == http.MethodPut {
+				etag = `"v2"`
+			}
+
+			w.Header().Set("ETag", etag)
+			w.Header().Set("Last-Modified", lastModified)
+			w.Header().Set("X-Query", r.URL.RawQuery)
+
+			if r.Header.Get(api.IfNoneMatch) == etag || r.Header.Get(api.IfModifiedSince) == lastModified {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"id": 1}`))
+		}))
+		defer server.Close()
+
+		cli, err := api.NewClient(&http.Client{})
+		So(err, ShouldBeNil)
+
+		So(cli.Replay(api.IfNoneMatch), ShouldBeLikeError, api.ErrNoRequest)
+
+		req := api.PrepareRequest(false).SetMethod(http.MethodGet).SetEndpoint(server.URL).AddArgument("expand", "all")
+		So(cli.EmitRequest(req), ShouldBeNil)
+
+		validators, err := cli.CaptureValidators()
+		So(err, ShouldBeNil)
+		So(validators, ShouldResemble, api.Validators{ETag: `"v1"`, LastModified: lastModified})
+
+		Convey("should replay request with conditions", func() {
+			So(cli.Replay(api.IfNoneMatch), ShouldBeNil)
+			So(cli.Response.IsNotModified(cli.CapturedValidators()), ShouldBeNil)
+			So(cli.Response.Headers.Get("X-Query"), ShouldEqual, "expand=all")
+
+			So(cli.Replay("if-modified-since"), ShouldBeNil)
+			So(cli.Response.IsNotModified(cli.CapturedValidators()), ShouldBeNil)
+		})
+
+		Convey("should detect lost updates", func() {
+			put, err := cli.SetCondition(api.PrepareRequest(false).SetMethod(http.MethodPut).SetEndpoint(server.URL), api.IfMatch)
+			So(err, ShouldBeNil)
+			So(put.Headers.Get(api.IfMatch), ShouldEqual, `"v1"`)
+
+			So(cli.EmitRequest(put), ShouldBeNil)
+			So(cli.Response.Status, ShouldEqual, http.StatusOK)
+
+			So(cli.Replay(api.IfMatch), ShouldBeNil)
+			So(cli.Response.Status, ShouldEqual, http.StatusPreconditionFailed)
+
+			So(cli.EmitRequest(req), ShouldBeNil)
+			So(cli.Replay(api.IfNoneMatch), ShouldBeNil)
+			So(cli.Response.IsNotModified(cli.CapturedValidators()), ShouldBeLikeError, api.ErrConditional)
+		})
+
+		Convey("should reject unknown conditions", func() {
+			So(cli.Replay("If-Range"), ShouldBeLikeError, api.ErrInvalidOption)
+
+			_, err := api.Validators{ETag: `"v1"`}.Condition(api.IfModifiedSince)
+			So(err, ShouldBeLikeError, api.ErrNoValidator)
+		})
+	})
+}
+
+func TestUnit_Response_CacheControl(t *testing.T) {
+	Convey("Given a response with cache directives", t, func() {
+		response := api.NewResponse(http.StatusOK, nil, nil, http.Header{
+			"Cache-Control": {`Private, max-age=300`, `no-cache="Set-Cookie", s-maxage=bad`},
+		})
+
+		Convey("should parse directives", func() {
+			So(response.CacheControl(), ShouldResemble, map[string]string{
+				"private": "", "max-age": "300", "no-cache": "Set-Cookie", "s-maxage": "bad",
+			})
+		})
+
+		Convey("should assert directives", func() {
+			So(response.CacheControlHas(false, "private"), ShouldBeNil)
+			So(response.CacheControlHas(true, "no-store"), ShouldBeNil)
+			So(response.CacheControlHas(false, "no-store"), ShouldBeLikeError, api.ErrCacheControl)
+			So(response.CacheControlHas(true, "PRIVATE"), ShouldBeLikeError, api.ErrCacheControl)
+
+			So(response.CacheControlAgeBetween("max-age", 60, 600), ShouldBeNil)
+			So(response.CacheControlAgeBetween("max-age", 300, -1), ShouldBeNil)
+			So(response.CacheControlAgeBetween("max-age", 0, 299), ShouldBeLikeError, api.ErrCacheControl)
+			So(response.CacheControlAgeBetween("s-maxage", 0, 10), ShouldBeLikeError, api.ErrCacheControl)
+			So(response.CacheControlAgeBetween("stale-if-error", 0, 10), ShouldBeLikeError, api.ErrCacheControl)
+		})
+	})
+}
@@ -1,32 +1,59 @@
 package api
 
 import (
+	"context"
+	"crypto/tls"
 	"errors"
+	"fmt"
 	"io"
 	"io/ioutil"
+	"net"
 	"net/http"
-	"net/http/cookiejar"
 	"net/http/httptrace"
+	"time"
 
 	"go.uber.org/zap"
 )
 
-var ErrNoRequest = errors.New("trying to emit empty request")
+var (
+	ErrNoRequest = errors.New("trying to emit empty request")
+	// ErrTimeout is thrown when a request is not answered within its timeout.
+	ErrTimeout = errors.New("request timed out")
+)
 
 type Client struct {
 	client        *http.Client
 	trace         *httptrace.ClientTrace
 	initialClient *http.Client
+	transport     *debugTransport
+	tlsConfig     *tls.Config
+	protocol      string
 
 	// Current storage
 	request      *http.Request
 	httpResponse *http.Response
 	Response     *Response
 	tracing      bool
+	signer       Signer
+	socket       string
+
+	// last is last emitted request preparation, replayed by conditional requests.
+	last       RequestPreparation
+	validators Validators
+
+	// streaming writes response bodies to downloads files.
+	streaming bool
+	downloads []string
+
+	// timeout applies to requests without their own timeout.
+	// It is kept on Reset.
+	timeout time.Duration
+	// sessions are saved cookie jars, kept on Reset.
+	sessions map[string][]jarEntry
 }
 
 func NewClient(cli *http.Client) (*Client, error) {
-	jar, err := cookiejar.New(&cookiejar.Options{PublicSuffixList: nil})
+	jar, err := newSessionJar(nil)
 	if err != nil {
 		return nil, err
 	}
@@ -56,13 +83,22 @@ func NewClient(cli *http.Client) (*Client, error) {
 
 	defaultCli := *cli
 
-	return &Client{client: cli, initialClient: &defaultCli, trace: trace}, nil
+	return &Client{client: cli, initialClient: &defaultCli, trace: trace, transport: debug}, nil
 }
 
 func (cli *Client) Reset() {
 	cli.request = nil
 	cli.httpResponse = nil
 	cli.Response = nil
+	cli.signer = nil
+	cli.tlsConfig = nil
+	cli.protocol = ""
+	cli.socket = ""
+	cli.last = RequestPreparation{}
+	cli.validators = Validators{}
+	cli.transport.closeSockets()
+	cli.streaming = false
+	cli.removeDownloads()
 
 	newCli, err := NewClient(cli.initialClient)
 	if err != nil {
@@ -70,16 +106,34 @@ func (cli *Client) Reset() {
 	}
 
 	cli.client = newCli.client
+	cli.transport = newCli.transport
 }
 
 func (cli *Client) SetTrace(activate bool) {
 	cli.tracing = activate
 }
 
+// SetDefaultTimeout sets timeout applied to requests without their own timeout.
+// Zero disables it.
+func (cli *Client) SetDefaultTimeout(timeout time.Duration) {
+	cli.timeout = timeout
+}
+
+// SetSigner sets signer applied to each emitted request.
+// Providing nil disables signing.
+func (cli *Client) SetSigner(signer Signer) {
+	cli.signer = signer
+}
+
 func (cli *Client) SetFollowRedirection(follow bool) {
 	if follow {
-		cli.client.CheckRedirect = func(_ *http.Request, _ []*http.Request) error {
+		cli.client.CheckRedirect = func(_ *http.Request, via []*http.Request) error {
 			log.Debug("follow redirect")
+
+			if len(via) >= maxRedirects {
+				return ErrTooManyRedirects
+			}
+
 			return nil
 		}
 	} else {
@@ -95,11 +149,30 @@ func (cli *Client) EmitRequest(req RequestPreparation) (err error) {
 		return ErrNoRequest
 	}
 
-	cli.request, err = req.GenerateRequest(cli.client.Jar)
+	cli.request, err = cli.generateRequest(req)
 	if err != nil {
 		return err
 	}
 
+	cli.last = req
+
+	if cli.signer != nil {
+		// request shares preparation headers, signing must not alter them.
+		cli.request.Header = cli.request.Header.Clone()
+
+		if err = cli.signer.Sign(cli.request); err != nil {
+			return err
+		}
+	}
+
+	timeout := cli.timeoutFor(req)
+	if timeout > 0 {
+		ctx, cancel := context.WithTimeout(cli.request.Context(), timeout)
+		defer cancel()
+
+		cli.request = cli.request.WithContext(ctx)
+	}
+
 	if cli.tracing {
 		cli.request = cli.request.WithContext(
 			httptrace.WithClientTrace(cli.request.Context(), cli.trace),
@@ -109,7 +182,7 @@ func (cli *Client) EmitRequest(req RequestPreparation) (err error) {
 	// nolint: bodyclose
 	cli.httpResponse, err = cli.client.Do(cli.request)
 	if err != nil {
-		return
+		return timeoutError(err, timeout)
 	}
 
 	defer func() {
@@ -125,13 +198,73 @@ func (cli *Client) EmitRequest(req RequestPreparation) (err error) {
 		}
 	}()
 
-	body, errBody := ioutil.ReadAll(cli.httpResponse.Body)
+	var (
+		body     []byte
+		download *Download
+	)
+
+	encoding := cli.httpResponse.Header.Get("Content-Encoding")
+
+	if cli.streaming {
+		var stream io.Reader = cli.httpResponse.Body
 
-	if errBody != nil {
-		return errBody
+		if !cli.httpResponse.Uncompressed && encoding != "" {
+			decoded, decodeErr := DecompressReader(encoding, stream)
+			if decodeErr != nil {
+				return decodeErr
+			}
+
+			defer decoded.Close()
+
+			stream = decoded
+		}
+
+		if download, err = cli.streamBody(stream); err != nil {
+			return timeoutError(err, timeout)
+		}
+	} else {
+		if body, err = ioutil.ReadAll(cli.httpResponse.Body); err != nil {
+			return timeoutError(err, timeout)
+		}
+
+		// 204, 304 and HEAD responses may announce an encoding without any body.
+		if !cli.httpResponse.Uncompressed && encoding != "" && len(body) > 0 {
+			if body, err = Decompress(encoding, body); err != nil {
+				return err
+			}
+		}
+	}
+
+	if cli.httpResponse.Uncompressed {
+		// transport already decoded gzip body and removed the header.
+		encoding = "gzip"
 	}
 
 	cli.Response = NewResponse(cli.httpResponse.StatusCode, body, cli.httpResponse.Cookies(), cli.httpResponse.Header)
+	cli.Response.ContentEncoding = NormalizeEncoding(encoding)
+	cli.Response.Protocol = cli.httpResponse.Proto
+	cli.Response.URL = cli.httpResponse.Request.URL.String()
+	cli.Response.Redirects = redirectChain(cli.httpResponse)
+	cli.Response.Download = download
 
 	return
 }
+
+func (cli *Client) timeoutFor(req RequestPreparation) time.Duration {
+	if req.Timeout > 0 {
+		return req.Timeout
+	}
+
+	return cli.timeout
+}
+
+// timeoutError wraps deadline errors in ErrTimeout.
+func timeoutError(err error, timeout time.Duration) error {
+	var netErr net.Error
+
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return fmt.Errorf("%w after %s: %w", ErrTimeout, timeout, err)
+	}
+
+	return err
+}
@@ -0,0 +1,305 @@
+package api
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"strings"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// Known binary media types.
+const (
+	MsgpackContentType  = "application/msgpack"
+	CBORContentType     = "application/cbor"
+	ProtobufContentType = "application/x-protobuf"
+)
+
+var (
+	// ErrUnknownCodec is thrown when no codec matches a media type or format.
+	ErrUnknownCodec = errors.New("unknown body codec")
+	// ErrNoProtobufMessage is thrown when decoding protobuf without a known message type.
+	ErrNoProtobufMessage = errors.New("protobuf message type is required to decode body")
+	// ErrUnknownMessage is thrown when a protobuf message is not in provided descriptors.
+	ErrUnknownMessage = errors.New("unknown protobuf message")
+)
+
+type (
+	// Codec converts bodies from/to the generic tree produced by JSON decoding:
+	// map[string]interface{}, []interface{}, float64, string, bool and nil.
+	Codec interface {
+		ContentType() string
+		Marshal(tree interface{}) ([]byte, error)
+		Unmarshal(data []byte) (interface{}, error)
+	}
+
+	jsonCodec    struct{}
+	msgpackCodec struct{}
+	cborCodec    struct{}
+
+	// ProtobufCodec converts protobuf messages using the proto3 JSON mapping.
+	ProtobufCodec struct {
+		message protoreflect.MessageDescriptor
+	}
+)
+
+// CodecFor returns codec matching a media type or a format name (json, msgpack, cbor).
+// Protobuf codecs require a message type and are built by NewProtobufCodec.
+func CodecFor(mediaTypeOrFormat string) (Codec, error) {
+	mediaType, _, err := mime.ParseMediaType(mediaTypeOrFormat)
+	if err != nil {
+		mediaType = strings.ToLower(strings.TrimSpace(mediaTypeOrFormat))
+	}
+
+	switch {
+	case mediaType == "", mediaType == "json", mediaType == "application/json", strings.HasSuffix(mediaType, "+json"):
+		return jsonCodec{}, nil
+	case mediaType == "msgpack", mediaType == MsgpackContentType,
+		mediaType == "application/x-msgpack", mediaType == "application/vnd.msgpack":
+		return msgpackCodec{}, nil
+	case mediaType == "cbor", mediaType == CBORContentType, strings.HasSuffix(mediaType, "+cbor"):
+		return cborCodec{}, nil
+	case mediaType == "protobuf", mediaType == ProtobufContentType,
+		mediaType == "application/protobuf", mediaType == "application/vnd.google.protobuf":
+		return nil, ErrNoProtobufMessage
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUnknownCodec, mediaTypeOrFormat)
+}
+
+// NewProtobufCodec loads message type from a binary FileDescriptorSet file
+// (protoc --include_imports --descriptor_set_out).
+func NewProtobufCodec(descriptorSetPath, messageName string) (*ProtobufCodec, error) {
+	raw, err := ioutil.ReadFile(descriptorSetPath)
+	if err != nil {
+		return nil, err
+	}
+
+	set := &descriptorpb.FileDescriptorSet{}
+	if err = proto.Unmarshal(raw, set); err != nil {
+		return nil, err
+	}
+
+	files, err := protodesc.NewFiles(set)
+	if err != nil {
+		return nil, err
+	}
+
+	descriptor, err := files.FindDescriptorByName(protoreflect.FullName(messageName))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownMessage, messageName)
+	}
+
+	message, ok := descriptor.(protoreflect.MessageDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s is not a message", ErrUnknownMessage, messageName)
+	}
+
+	return &ProtobufCodec{message: message}, nil
+}
+
+// EncodeJSON converts a JSON document to codec format.
+// Integers are kept as integers so binary formats do not encode them as floats.
+func EncodeJSON(codec Codec, document string) ([]byte, error) {
+	if _, ok := codec.(jsonCodec); ok {
+		return []byte(document), nil
+	}
+
+	if protobuf, ok := codec.(*ProtobufCodec); ok {
+		return protobuf.fromJSON([]byte(document))
+	}
+
+	var tree interface{}
+
+	decoder := json.NewDecoder(strings.NewReader(document))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&tree); err != nil {
+		return nil, err
+	}
+
+	return codec.Marshal(fromJSONNumbers(tree))
+}
+
+func (jsonCodec) ContentType() string { return "application/json" }
+
+func (jsonCodec) Marshal(tree interface{}) ([]byte, error) { return json.Marshal(tree) }
+
+func (jsonCodec) Unmarshal(data []byte) (interface{}, error) {
+	var tree interface{}
+
+	if err := json.Unmarshal(data, &tree); err != nil {
+		return nil, jsonSyntaxError(data, err)
+	}
+
+	return tree, nil
+}
+
+func (msgpackCodec) ContentType() string { return MsgpackContentType }
+
+func (msgpackCodec) Marshal(tree interface{}) ([]byte, error) { return msgpack.Marshal(tree) }
+
+func (msgpackCodec) Unmarshal(data []byte) (interface{}, error) {
+	var tree interface{}
+
+	if err := msgpack.Unmarshal(data, &tree); err != nil {
+		return nil, err
+	}
+
+	return normalizeTree(tree), nil
+}
+
+func (cborCodec) ContentType() string { return CBORContentType }
+
+func (cborCodec) Marshal(tree interface{}) ([]byte, error) { return cbor.Marshal(tree) }
+
+func (cborCodec) Unmarshal(data []byte) (interface{}, error) {
+	var tree interface{}
+
+	if err := cbor.Unmarshal(data, &tree); err != nil {
+		return nil, err
+	}
+
+	return normalizeTree(tree), nil
+}
+
+// ContentType returns protobuf media type.
+func (codec *ProtobufCodec) ContentType() string { return ProtobufContentType }
+
+// Marshal encodes a generic tree as a protobuf message.
+func (codec *ProtobufCodec) Marshal(tree interface{}) ([]byte, error) {
+	document, err := json.Marshal(tree)
+	if err != nil {
+		return nil, err
+	}
+
+	return codec.fromJSON(document)
+}
+
+// Unmarshal decodes a protobuf message to a generic tree.
+func (codec *ProtobufCodec) Unmarshal(data []byte) (interface{}, error) {
+	message := dynamicpb.NewMessage(codec.message)
+	if err := proto.Unmarshal(data, message); err != nil {
+		return nil, err
+	}
+
+	document, err := protojson.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+
+	return jsonCodec{}.Unmarshal(document)
+}
+
+func (codec *ProtobufCodec) fromJSON(document []byte) ([]byte, error) {
+	message := dynamicpb.NewMessage(codec.message)
+	if err := protojson.Unmarshal(document, message); err != nil {
+		return nil, err
+	}
+
+	return proto.Marshal(message)
+}
+
+// fromJSONNumbers converts json.Number to int64 when possible, float64 otherwise.
+func fromJSONNumbers(tree interface{}) interface{} {
+	switch value := tree.(type) {
+	case map[string]interface{}:
+		for key, child := range value {
+			value[key] = fromJSONNumbers(child)
+		}
+	case []interface{}:
+		for i, child := range value {
+			value[i] = fromJSONNumbers(child)
+		}
+	case json.Number:
+		if i, err := value.Int64(); err == nil {
+			return i
+		}
+
+		f, _ := value.Float64() // nolint: errcheck
+		return f
+	}
+
+	return tree
+}
+
+// normalizeTree converts decoded binary values to JSON decoding types.
+func normalizeTree(tree interface{}) interface{} { // nolint: gocyclo
+	switch value := tree.(type) {
+	case map[string]interface{}:
+		for key, child := range value {
+			value[key] = normalizeTree(child)
+		}
+
+		return value
+	case map[interface{}]interface{}:
+		converted := make(map[string]interface{}, len(value))
+
+		for key, child := range value {
+			converted[fmt.Sprint(key)] = normalizeTree(child)
+		}
+
+		return converted
+	case []interface{}:
+		for i, child := range value {
+			value[i] = normalizeTree(child)
+		}
+
+		return value
+	case []byte:
+		return base64.StdEncoding.EncodeToString(value)
+	case int8:
+		return float64(value)
+	case int16:
+		return float64(value)
+	case int32:
+		return float64(value)
+	case int64:
+		return float64(value)
+	case int:
+		return float64(value)
+	case uint8:
+		return float64(value)
+	case uint16:
+		return float64(value)
+	case uint32:
+		return float64(value)
+	case uint64:
+		return float64(value)
+	case uint:
+		return float64(value)
+	case float32:
+		return float64(value)
+	}
+
+	return tree
+}
+
+// decodeBody decodes body with codec, falling back on content type, then JSON.
+func decodeBody(body []byte, codec Codec, contentType string) (interface{}, error) {
+	if codec == nil {
+		var err error
+
+		if codec, err = CodecFor(contentType); err != nil {
+			if errors.Is(err, ErrUnknownCodec) {
+				codec = jsonCodec{}
+			} else {
+				return nil, err
+			}
+		}
+	}
+
+	return codec.Unmarshal(bytes.TrimPrefix(body, []byte("\xef\xbb\xbf")))
+}
@@ -0,0 +1,189 @@
+package api_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/cucumber/godog"
+	"github.com/fxamacker/cbor/v2"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/elmagician/kactus/internal/api"
+	. "github.com/elmagician/kactus/internal/test"
+)
+
+func TestUnit_Codecs(t *testing.T) {
+	Convey("Given binary encoded bodies", t, func() {
+		doc := &godog.DocString{Content: `{"id": 42, "name": "kactus", "tags": ["a", "b"], "ratio": 0.5}`}
+		expected := map[string]interface{}{
+			"id": 42.0, "name": "kactus", "tags": []interface{}{"a", "b"}, "ratio": 0.5,
+		}
+
+		for _, format := range []string{"msgpack", "cbor"} {
+			format := format
+
+			Convey("should round trip "+format+" bodies", func() {
+				codec, err := api.CodecFor(format)
+				So(err, ShouldBeNil)
+
+				req, err := api.PrepareRequest(false).SetMethod("POST").SetEndpoint("http://localhost").SetEncodedBody(codec, doc)
+				So(err, ShouldBeNil)
+
+				generated, err := req.GenerateRequest(nil)
+				So(err, ShouldBeNil)
+				So(generated.Header.Get("Content-Type"), ShouldEqual, codec.ContentType())
+
+				raw, err := ioutil.ReadAll(generated.Body)
+				So(err, ShouldBeNil)
+
+				response := api.NewResponse(http.StatusOK, raw, nil, http.Header{"Content-Type": {codec.ContentType()}})
+
+				decoded, err := response.Decode()
+				So(err, ShouldBeNil)
+				So(decoded, ShouldResemble, expected)
+
+				name, err := response.RetrieveJSON("tags.1")
+				So(err, ShouldBeNil)
+				So(name, ShouldEqual, "b")
+			})
+		}
+
+		Convey("should keep integers as integers", func() {
+			codec, err := api.CodecFor("application/msgpack")
+			So(err, ShouldBeNil)
+
+			raw, err := api.EncodeJSON(codec, `{"id": 42}`)
+			So(err, ShouldBeNil)
+
+			var decoded map[string]interface{}
+			So(msgpack.Unmarshal(raw, &decoded), ShouldBeNil)
+			So(decoded["id"], ShouldEqual, int8(42))
+
+			codec, err = api.CodecFor("cbor")
+			So(err, ShouldBeNil)
+
+			raw, err = api.EncodeJSON(codec, `{"id": 42}`)
+			So(err, ShouldBeNil)
+
+			decoded = nil
+			So(cbor.Unmarshal(raw, &decoded), ShouldBeNil)
+			So(decoded["id"], ShouldEqual, uint64(42))
+		})
+
+		Convey("should decode from Content-Type in assertions", func() {
+			raw, err := cbor.Marshal(map[string]interface{}{"items": []interface{}{1, 2}})
+			So(err, ShouldBeNil)
+
+			response := api.NewResponse(http.StatusOK, raw, nil, http.Header{"Content-Type": {"application/cbor"}})
+
+			items, err := response.RetrieveJSONArray("items")
+			So(err, ShouldBeNil)
+			So(items, ShouldResemble, []interface{}{1.0, 2.0})
+
+			So(response.JSONIncludes(&godog.DocString{Content: `{"items": [2]}`}, true), ShouldBeNil)
+		})
+
+		Convey("should reject unknown formats", func() {
+			_, err := api.CodecFor("application/xml")
+			So(err, ShouldBeLikeError, api.ErrUnknownCodec)
+
+			_, err = api.CodecFor("application/x-protobuf")
+			So(err, ShouldBeLikeError, api.ErrNoProtobufMessage)
+		})
+	})
+}
+
+func TestUnit_ProtobufCodec(t *testing.T) {
+	Convey("Given a protobuf descriptor set", t, func() {
+		descriptorSet := writeDescriptorSet(t)
+
+		Convey("should encode and decode messages", func() {
+			codec, err := api.NewProtobufCodec(descriptorSet, "kactus.test.User")
+			So(err, ShouldBeNil)
+
+			var received []byte
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received, _ = ioutil.ReadAll(r.Body) // nolint: errcheck
+
+				w.Header().Set("Content-Type", api.ProtobufContentType)
+				_, _ = w.Write(received) // nolint: errcheck
+			}))
+			defer server.Close()
+
+			cli, err := api.NewClient(&http.Client{})
+			So(err, ShouldBeNil)
+
+			req, err := api.PrepareRequest(false).SetMethod("POST").SetEndpoint(server.URL).
+				SetEncodedBody(codec, &godog.DocString{Content: `{"id": "12", "name": "kactus", "roles": ["admin"]}`})
+			So(err, ShouldBeNil)
+			So(cli.EmitRequest(req), ShouldBeNil)
+			So(received, ShouldNotBeEmpty)
+
+			_, err = cli.Response.Decode()
+			So(err, ShouldBeLikeError, api.ErrNoProtobufMessage)
+
+			cli.Response.Codec = codec
+
+			decoded, err := cli.Response.Decode()
+			So(err, ShouldBeNil)
+			So(decoded, ShouldResemble, map[string]interface{}{
+				"id": "12", "name": "kactus", "roles": []interface{}{"admin"},
+			})
+		})
+
+		Convey("should reject unknown messages", func() {
+			_, err := api.NewProtobufCodec(descriptorSet, "kactus.test.Unknown")
+
+			So(err, ShouldBeLikeError, api.ErrUnknownMessage)
+		})
+	})
+}
+
+// writeDescriptorSet writes a descriptor set for:
+//
+//	package kactus.test;
+//	message User { int64 id = 1; string name = 2; repeated string roles = 3; }
+func writeDescriptorSet(t *testing.T) string {
+	field := func(name string, number int32, kind descriptorpb.FieldDescriptorProto_Type, label descriptorpb.FieldDescriptorProto_Label) *descriptorpb.FieldDescriptorProto {
+		return &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(number),
+			Type:     kind.Enum(),
+			Label:    label.Enum(),
+		}
+	}
+
+	set := &descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{{
+		Name:    proto.String("user.proto"),
+		Package: proto.String("kactus.test"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("User"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				field("id", 1, descriptorpb.FieldDescriptorProto_TYPE_INT64, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL),
+				field("name", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL),
+				field("roles", 3, descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_LABEL_REPEATED),
+			},
+		}},
+	}}}
+
+	raw, err := proto.Marshal(set)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "user.pb")
+	if err = ioutil.WriteFile(path, raw, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
@@ -0,0 +1,195 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cucumber/godog"
+
+	"github.com/elmagician/kactus/internal/interfaces"
+)
+
+var (
+	// ErrNotAnArray is thrown when a collection assertion targets a non array value.
+	ErrNotAnArray = errors.New("value is not an array")
+	// ErrCollection is thrown when a collection does not satisfy assertion.
+	ErrCollection = errors.New("collection does not match expected")
+	// ErrNotComparable is thrown when collection values cannot be ordered.
+	ErrNotComparable = errors.New("values cannot be compared")
+)
+
+// RetrieveJSONArray retrieves array stored under path in response JSON body.
+// An empty path retrieves body root.
+func (r Response) RetrieveJSONArray(path string) ([]interface{}, error) {
+	var (
+		value interface{}
+		err   error
+	)
+
+	if path == "" {
+		if err = r.bodyError(); err != nil {
+			return nil, err
+		}
+
+		value, err = r.Decode()
+	} else {
+		value, err = r.RetrieveJSON(path)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return AsArray(value)
+}
+
+// AsArray converts a decoded JSON value to an array.
+func AsArray(value interface{}) ([]interface{}, error) {
+	switch items := value.(type) {
+	case []interface{}:
+		return items, nil
+	case nil:
+		return nil, fmt.Errorf("%w: got null", ErrNotAnArray)
+	default:
+		return nil, fmt.Errorf("%w: got %T", ErrNotAnArray, value)
+	}
+}
+
+// EveryItemMatches asserts each item matches a `field | matcher | value` table.
+func EveryItemMatches(items []interface{}, expected *godog.Table) error {
+	for i, item := range items {
+		if err := FieldsMatch(item, expected); err != nil {
+			return fmt.Errorf("%w: item %d: %s", ErrCollection, i, err.Error())
+		}
+	}
+
+	return nil
+}
+
+// AnyItemMatches asserts at least one item matches a `field | matcher | value` table.
+func AnyItemMatches(items []interface{}, expected *godog.Table) error {
+	for _, item := range items {
+		if FieldsMatch(item, expected) == nil {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: no item matches among %d", ErrCollection, len(items))
+}
+
+// NoItemMatches asserts no item matches a `field | matcher | value` table.
+func NoItemMatches(items []interface{}, expected *godog.Table) error {
+	for i, item := range items {
+		if FieldsMatch(item, expected) == nil {
+			return fmt.Errorf("%w: item %d matches", ErrCollection, i)
+		}
+	}
+
+	return nil
+}
+
+// HasLength asserts collection has expected number of items.
+func HasLength(items []interface{}, expected int) error {
+	if len(items) != expected {
+		return fmt.Errorf("%w: expected %d elements, got %d", ErrCollection, expected, len(items))
+	}
+
+	return nil
+}
+
+// IsSortedBy asserts items are sorted using value under field.
+// An empty field sorts on items themselves. Numbers and strings are supported.
+func IsSortedBy(items []interface{}, field string, descending bool) error {
+	for i := 1; i < len(items); i++ {
+		previous, err := itemField(items[i-1], field)
+		if err != nil {
+			return err
+		}
+
+		current, err := itemField(items[i], field)
+		if err != nil {
+			return err
+		}
+
+		cmp, err := compareValues(previous, current)
+		if err != nil {
+			return err
+		}
+
+		if (descending && cmp < 0) || (!descending && cmp > 0) {
+			order := "ascending"
+			if descending {
+				order = "descending"
+			}
+
+			return fmt.Errorf(
+				"%w: items %d (%v) and %d (%v) are not in %s order", ErrCollection, i-1, previous, i, current, order,
+			)
+		}
+	}
+
+	return nil
+}
+
+// HasUnique asserts value under field is unique among items.
+// An empty field checks items themselves.
+func HasUnique(items []interface{}, field string) error {
+	seen := make(map[string]int)
+
+	for i, item := range items {
+		value, err := itemField(item, field)
+		if err != nil {
+			return err
+		}
+
+		key, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+
+		if first, exists := seen[string(key)]; exists {
+			return fmt.Errorf("%w: items %d and %d share %s %s", ErrCollection, first, i, field, key)
+		}
+
+		seen[string(key)] = i
+	}
+
+	return nil
+}
+
+func itemField(item interface{}, field string) (interface{}, error) {
+	if field == "" || field == "." {
+		return item, nil
+	}
+
+	value, exists := interfaces.GetFieldFromPath(item, field)
+	if !exists || !value.IsValid() {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKey, field)
+	}
+
+	return value.Interface(), nil
+}
+
+func compareValues(a, b interface{}) (int, error) {
+	switch aVal := a.(type) {
+	case float64:
+		if bVal, ok := b.(float64); ok {
+			switch {
+			case aVal < bVal:
+				return -1, nil
+			case aVal > bVal:
+				return 1, nil
+			default:
+				return 0, nil
+			}
+		}
+	case string:
+		if bVal, ok := b.(string); ok {
+			return strings.Compare(aVal, bVal), nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: %v (%T) and %v (%T)", ErrNotComparable, a, a, b, b)
+}
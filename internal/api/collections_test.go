@@ -0,0 +1,83 @@
+package api_test
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/elmagician/kactus/internal/api"
+	. "github.com/elmagician/kactus/internal/test"
+)
+
+func TestUnit_Response_RetrieveJSONArray(t *testing.T) {
+	Convey("When I retrieve a JSON array from response", t, func() {
+		r := api.Response{Body: []byte(`{"items": [1, 2], "name": "list"}`)}
+
+		Convey("should succeed on array path", func() {
+			items, err := r.RetrieveJSONArray("items")
+
+			So(err, ShouldBeNil)
+			So(items, ShouldHaveLength, 2)
+		})
+
+		Convey("should fail on non array values", func() {
+			_, err := r.RetrieveJSONArray("name")
+			So(err, ShouldBeLikeError, api.ErrNotAnArray)
+
+			_, err = r.RetrieveJSONArray("")
+			So(err, ShouldBeLikeError, api.ErrNotAnArray)
+		})
+
+		Convey("should use body root on empty path", func() {
+			r.Body = []byte(`[{"id": 1}]`)
+
+			items, err := r.RetrieveJSONArray("")
+
+			So(err, ShouldBeNil)
+			So(items, ShouldHaveLength, 1)
+		})
+	})
+}
+
+func TestUnit_CollectionAssertions(t *testing.T) {
+	Convey("Given a collection", t, func() {
+		items := []interface{}{
+			map[string]interface{}{"id": "a", "rank": float64(3), "active": true},
+			map[string]interface{}{"id": "b", "rank": float64(2), "active": true},
+			map[string]interface{}{"id": "c", "rank": float64(2), "active": false},
+		}
+
+		activeTable := NewTable([]string{"field", "matcher", "value"}, []string{"active", "=", "true((bool))"})
+		idTable := NewTable([]string{"field", "matcher", "value"}, []string{"id", "=", "z"})
+
+		Convey("every/any/none should match conditions", func() {
+			So(api.EveryItemMatches(items, NewTable([]string{"field", "matcher"}, []string{"id", "defined"})), ShouldBeNil)
+			So(api.EveryItemMatches(items, activeTable), ShouldBeLikeError, api.ErrCollection)
+
+			So(api.AnyItemMatches(items, activeTable), ShouldBeNil)
+			So(api.AnyItemMatches(items, idTable), ShouldBeLikeError, api.ErrCollection)
+
+			So(api.NoItemMatches(items, idTable), ShouldBeNil)
+			So(api.NoItemMatches(items, activeTable), ShouldBeLikeError, api.ErrCollection)
+		})
+
+		Convey("length should be asserted", func() {
+			So(api.HasLength(items, 3), ShouldBeNil)
+			So(api.HasLength(items, 2), ShouldBeLikeError, api.ErrCollection)
+		})
+
+		Convey("order should be asserted", func() {
+			So(api.IsSortedBy(items, "rank", true), ShouldBeNil)
+			So(api.IsSortedBy(items, "rank", false), ShouldBeLikeError, api.ErrCollection)
+			So(api.IsSortedBy(items, "id", false), ShouldBeNil)
+			So(api.IsSortedBy(items, "active", false), ShouldBeLikeError, api.ErrNotComparable)
+			So(api.IsSortedBy(items, "missing", false), ShouldBeLikeError, api.ErrUnknownKey)
+		})
+
+		Convey("uniqueness should be asserted", func() {
+			So(api.HasUnique(items, "id"), ShouldBeNil)
+			So(api.HasUnique(items, "rank"), ShouldBeLikeError, api.ErrCollection)
+			So(api.HasUnique([]interface{}{"a", "b", "a"}, ""), ShouldBeLikeError, api.ErrCollection)
+		})
+	})
+}
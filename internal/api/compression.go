@@ -0,0 +1,167 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+)
+
+const identityEncoding = "identity"
+
+// ErrUnsupportedEncoding is thrown when a content encoding is not supported.
+var ErrUnsupportedEncoding = errors.New("unsupported content encoding")
+
+// NormalizeEncoding returns the Content-Encoding token for an algorithm name.
+func NormalizeEncoding(algorithm string) string {
+	switch algorithm = strings.ToLower(strings.TrimSpace(algorithm)); algorithm {
+	case "brotli":
+		return "br"
+	case "x-gzip":
+		return "gzip"
+	case "":
+		return identityEncoding
+	default:
+		return algorithm
+	}
+}
+
+// Compress encodes data using algorithm (gzip, deflate, br or zstd).
+func Compress(algorithm string, data []byte) ([]byte, error) {
+	var (
+		buf    bytes.Buffer
+		writer io.WriteCloser
+		err    error
+	)
+
+	switch NormalizeEncoding(algorithm) {
+	case "gzip":
+		writer = gzip.NewWriter(&buf)
+	case "deflate":
+		writer = zlib.NewWriter(&buf)
+	case "br":
+		writer = brotli.NewWriter(&buf)
+	case "zstd":
+		if writer, err = zstd.NewWriter(&buf); err != nil {
+			return nil, err
+		}
+	case identityEncoding:
+		return data, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedEncoding, algorithm)
+	}
+
+	if _, err = writer.Write(data); err != nil {
+		return nil, err
+	}
+
+	if err = writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Decompress decodes data encoded using a Content-Encoding header value.
+// Multiple encodings are decoded in reverse order of application.
+func Decompress(contentEncoding string, data []byte) ([]byte, error) {
+	reader, err := DecompressReader(contentEncoding, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	defer reader.Close()
+
+	return ioutil.ReadAll(reader)
+}
+
+// decoders wrap a reader decoding a content encoding.
+var decoders = map[string]func(io.Reader) (io.ReadCloser, error){
+	"gzip": func(r io.Reader) (io.ReadCloser, error) {
+		return gzip.NewReader(r)
+	},
+	"deflate": func(r io.Reader) (io.ReadCloser, error) {
+		// deflate content encoding should be zlib wrapped but some servers send raw deflate.
+		buffered := bufio.NewReader(r)
+		if header, err := buffered.Peek(2); err == nil && header[0]&0x0f == 8 &&
+			(uint16(header[0])<<8|uint16(header[1]))%31 == 0 {
+			return zlib.NewReader(buffered)
+		}
+
+		return flate.NewReader(buffered), nil
+	},
+	"br": func(r io.Reader) (io.ReadCloser, error) {
+		return ioutil.NopCloser(brotli.NewReader(r)), nil
+	},
+	"zstd": func(r io.Reader) (io.ReadCloser, error) {
+		decoder, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+
+		return decoder.IOReadCloser(), nil
+	},
+	identityEncoding: func(r io.Reader) (io.ReadCloser, error) {
+		return ioutil.NopCloser(r), nil
+	},
+}
+
+// DecompressReader decodes body encoded using a Content-Encoding header value
+// while it is read. Empty bodies are returned as is.
+func DecompressReader(contentEncoding string, body io.Reader) (io.ReadCloser, error) {
+	encodings := strings.Split(contentEncoding, ",")
+
+	for _, encoding := range encodings {
+		if _, ok := decoders[NormalizeEncoding(encoding)]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedEncoding, strings.TrimSpace(encoding))
+		}
+	}
+
+	buffered := bufio.NewReader(body)
+	if _, err := buffered.Peek(1); errors.Is(err, io.EOF) {
+		return ioutil.NopCloser(buffered), nil
+	}
+
+	layers := &decodingReader{Reader: buffered}
+
+	for i := len(encodings) - 1; i >= 0; i-- {
+		decoder, err := decoders[NormalizeEncoding(encodings[i])](layers.Reader)
+		if err != nil {
+			layers.Close() // nolint: errcheck
+
+			return nil, err
+		}
+
+		layers.Reader = decoder
+		layers.closers = append(layers.closers, decoder)
+	}
+
+	return layers, nil
+}
+
+// decodingReader reads the outermost decoding layer and closes all of them.
+type decodingReader struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (r *decodingReader) Close() error {
+	var err error
+
+	for i := len(r.closers) - 1; i >= 0; i-- {
+		if closeErr := r.closers[i].Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+
+	return err
+}
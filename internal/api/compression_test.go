@@ -0,0 +1,136 @@
+package api_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cucumber/godog"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/elmagician/kactus/internal/api"
+	. "github.com/elmagician/kactus/internal/test"
+)
+
+func TestUnit_Compression(t *testing.T) {
+	Convey("When I compress data", t, func() {
+		data := []byte(`{"message": "kactus kactus kactus kactus"}`)
+
+		for _, algorithm := range []string{"gzip", "deflate", "br", "brotli", "zstd", "identity"} {
+			algorithm := algorithm
+
+			Convey("it should be decompressed using "+algorithm, func() {
+				compressed, err := api.Compress(algorithm, data)
+				So(err, ShouldBeNil)
+
+				decompressed, err := api.Decompress(api.NormalizeEncoding(algorithm), compressed)
+				So(err, ShouldBeNil)
+				So(string(decompressed), ShouldEqual, string(data))
+			})
+		}
+
+		Convey("stacked encodings should be decoded in reverse order", func() {
+			gzipped, err := api.Compress("gzip", data)
+			So(err, ShouldBeNil)
+
+			stacked, err := api.Compress("zstd", gzipped)
+			So(err, ShouldBeNil)
+
+			decompressed, err := api.Decompress("gzip, zstd", stacked)
+			So(err, ShouldBeNil)
+			So(string(decompressed), ShouldEqual, string(data))
+		})
+
+		Convey("unknown algorithms should fail", func() {
+			_, err := api.Compress("lzma", data)
+			So(err, ShouldBeLikeError, api.ErrUnsupportedEncoding)
+
+			_, err = api.Decompress("lzma", data)
+			So(err, ShouldBeLikeError, api.ErrUnsupportedEncoding)
+		})
+	})
+}
+
+func TestUnit_Client_EmitRequest_Compression(t *testing.T) {
+	Convey("Given a server speaking brotli", t, func() {
+		var (
+			received []byte
+			encoding string
+		)
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			encoding = r.Header.Get("Content-Encoding")
+			raw, _ := ioutil.ReadAll(r.Body)                                    // nolint: errcheck
+			received, _ = api.Decompress(r.Header.Get("Content-Encoding"), raw) // nolint: errcheck
+
+			body, _ := api.Compress("br", []byte(`{"ok":true}`)) // nolint: errcheck
+
+			w.Header().Set("Content-Encoding", "br")
+			w.Write(body) // nolint: errcheck
+		}))
+		defer server.Close()
+
+		cli, err := api.NewClient(&http.Client{})
+		So(err, ShouldBeNil)
+
+		req := api.PrepareRequest(false).
+			SetMethod(http.MethodPost).
+			SetEndpoint(server.URL).
+			SetJSONBody(&godog.DocString{Content: `{"id":1}`}).
+			SetCompression("zstd")
+
+		So(cli.EmitRequest(req), ShouldBeNil)
+
+		Convey("request body should be sent compressed", func() {
+			So(string(received), ShouldEqual, `{"id":1}`)
+			So(encoding, ShouldEqual, "zstd")
+			So(req.Headers.Get("Content-Encoding"), ShouldBeEmpty)
+		})
+
+		Convey("reset body should not be compressed", func() {
+			req = req.ResetBody().SetJSONBody(&godog.DocString{Content: `{"id":2}`})
+
+			So(cli.EmitRequest(req), ShouldBeNil)
+			So(string(received), ShouldEqual, `{"id":2}`)
+			So(encoding, ShouldBeEmpty)
+		})
+
+		Convey("response body should be decoded", func() {
+			So(string(cli.Response.Body), ShouldEqual, `{"ok":true}`)
+			So(cli.Response.ContentEncoding, ShouldEqual, "br")
+		})
+	})
+}
+
+func TestUnit_Client_EmitRequest_EmptyEncodedBody(t *testing.T) {
+	Convey("Given a server answering encoded responses without body", t, func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Encoding", r.URL.Query().Get("encoding"))
+
+			if r.URL.Path == "/not-modified" {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		cli, err := api.NewClient(&http.Client{})
+		So(err, ShouldBeNil)
+
+		for path, status := range map[string]int{"/not-modified": http.StatusNotModified, "/no-content": http.StatusNoContent} {
+			for _, encoding := range []string{"gzip", "deflate"} {
+				req := api.PrepareRequest(false).
+					SetMethod(http.MethodGet).
+					SetEndpoint(server.URL+path).
+					AddArgument("encoding", encoding)
+
+				So(cli.EmitRequest(req), ShouldBeNil)
+				So(cli.Response.Status, ShouldEqual, status)
+				So(cli.Response.Body, ShouldBeEmpty)
+			}
+		}
+	})
+}
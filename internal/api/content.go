@@ -0,0 +1,181 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+)
+
+var (
+	// ErrContentType is thrown when response Content-Type does not match expected.
+	ErrContentType = errors.New("content type does not match expected")
+	// ErrMalformedBody is thrown when response body is not well formed.
+	ErrMalformedBody = errors.New("malformed body")
+	// ErrInvalidUTF8 is thrown when response body is not valid UTF-8.
+	ErrInvalidUTF8 = errors.New("body is not valid utf-8")
+)
+
+// html void elements never have an end tag.
+var voidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true,
+	"input": true, "link": true, "meta": true, "param": true, "source": true, "track": true, "wbr": true,
+}
+
+// ContentTypeMatches asserts response Content-Type matches expected media type
+// and parameters, e.g. `application/json; charset=utf-8`. Media type and charset
+// are compared case insensitively. Parameters absent from expected are ignored.
+func (r Response) ContentTypeMatches(expected string) error {
+	actual := r.Headers.Get("Content-Type")
+
+	wantType, wantParams, err := mime.ParseMediaType(expected)
+	if err != nil {
+		return err
+	}
+
+	gotType, gotParams, err := mime.ParseMediaType(actual)
+	if err != nil {
+		return fmt.Errorf("%w: invalid Content-Type %q: %w", ErrContentType, actual, err)
+	}
+
+	if gotType != wantType {
+		return fmt.Errorf("%w: expected %s, got %s", ErrContentType, expected, actual)
+	}
+
+	for key, want := range wantParams {
+		got, ok := gotParams[key]
+		if !ok || (got != want && !(key == "charset" && strings.EqualFold(got, want))) {
+			return fmt.Errorf("%w: expected %s, got %s", ErrContentType, expected, actual)
+		}
+	}
+
+	return nil
+}
+
+// IsWellFormed asserts response body is well formed json, xml or html.
+// HTML check is lenient like browsers are: it only rejects end tags
+// without matching open element.
+func (r Response) IsWellFormed(format string) error {
+	if err := r.bodyError(); err != nil {
+		return err
+	}
+
+	switch strings.ToLower(format) {
+	case "json":
+		var value interface{}
+
+		return jsonSyntaxError(r.Body, json.Unmarshal(r.Body, &value))
+	case "xml":
+		return wellFormedXML(r.Body)
+	case "html":
+		return wellFormedHTML(r.Body)
+	default:
+		return fmt.Errorf("%w: unknown format %s", ErrMalformedBody, format)
+	}
+}
+
+// IsValidUTF8 asserts response body is valid UTF-8.
+func (r Response) IsValidUTF8() error {
+	if err := r.streamedError(); err != nil {
+		return err
+	}
+
+	for offset := 0; offset < len(r.Body); {
+		char, size := utf8.DecodeRune(r.Body[offset:])
+		if char == utf8.RuneError && size <= 1 {
+			return fmt.Errorf("%w: invalid byte 0x%x at offset %d", ErrInvalidUTF8, r.Body[offset], offset)
+		}
+
+		offset += size
+	}
+
+	return nil
+}
+
+// jsonSyntaxError adds position to json decoding errors.
+func jsonSyntaxError(data []byte, err error) error {
+	var syntaxErr *json.SyntaxError
+
+	if !errors.As(err, &syntaxErr) {
+		return err
+	}
+
+	line, column := position(data, syntaxErr.Offset)
+
+	return fmt.Errorf("%w: %w at line %d, column %d", ErrMalformedBody, err, line, column)
+}
+
+func wellFormedXML(data []byte) error {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+
+	for {
+		_, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			line, column := position(data, decoder.InputOffset())
+			return fmt.Errorf("%w: %w at line %d, column %d", ErrMalformedBody, err, line, column)
+		}
+	}
+}
+
+func wellFormedHTML(data []byte) error {
+	var open []string
+
+	tokenizer := html.NewTokenizer(bytes.NewReader(data))
+
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			if errors.Is(tokenizer.Err(), io.EOF) {
+				return nil
+			}
+
+			return fmt.Errorf("%w: %w", ErrMalformedBody, tokenizer.Err())
+		case html.StartTagToken:
+			name, _ := tokenizer.TagName()
+			if !voidElements[string(name)] {
+				open = append(open, string(name))
+			}
+		case html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			if voidElements[string(name)] {
+				continue
+			}
+
+			// unclosed elements are implicitly closed by their parent end tag.
+			i := len(open) - 1
+			for i >= 0 && open[i] != string(name) {
+				i--
+			}
+
+			if i < 0 {
+				return fmt.Errorf("%w: unexpected end tag </%s>", ErrMalformedBody, name)
+			}
+
+			open = open[:i]
+		}
+	}
+}
+
+// position returns 1 based line and column of byte offset in data.
+func position(data []byte, offset int64) (line, column int) {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+
+	before := data[:offset]
+	line = bytes.Count(before, []byte("\n")) + 1
+	column = int(offset) - bytes.LastIndexByte(before, '\n')
+
+	return line, column
+}
@@ -0,0 +1,66 @@
+package api_test
+
+import (
+	"net/http"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/elmagician/kactus/internal/api"
+	. "github.com/elmagician/kactus/internal/test"
+)
+
+func TestUnit_Response_ContentTypeMatches(t *testing.T) {
+	Convey("Given a response with a Content-Type", t, func() {
+		response := api.NewResponse(http.StatusOK, nil, nil, http.Header{
+			"Content-Type": {"application/json; charset=UTF-8; profile=v1"},
+		})
+
+		So(response.ContentTypeMatches("application/json"), ShouldBeNil)
+		So(response.ContentTypeMatches("application/json; charset=utf-8"), ShouldBeNil)
+		So(response.ContentTypeMatches("Application/JSON; profile=v1"), ShouldBeNil)
+		So(response.ContentTypeMatches("application/xml"), ShouldBeLikeError, api.ErrContentType)
+		So(response.ContentTypeMatches("application/json; charset=latin1"), ShouldBeLikeError, api.ErrContentType)
+		So(response.ContentTypeMatches("application/json; version=2"), ShouldBeLikeError, api.ErrContentType)
+	})
+}
+
+func TestUnit_Response_IsWellFormed(t *testing.T) {
+	Convey("When I check body well formedness", t, func() {
+		check := func(format, body string) error {
+			return api.NewResponse(http.StatusOK, []byte(body), nil, http.Header{}).IsWellFormed(format)
+		}
+
+		So(check("json", `{"a": [1, 2]}`), ShouldBeNil)
+		So(check("xml", `<?xml version="1.0"?><a><b x="1"/></a>`), ShouldBeNil)
+		So(check("html", `<!DOCTYPE html><html><body><p>a<br>b<ul><li>c</ul></body></html>`), ShouldBeNil)
+
+		err := check("json", "{\n  \"a\": [1, 2,]\n}")
+		So(err, ShouldBeLikeError, api.ErrMalformedBody)
+		So(err.Error(), ShouldContainSubstring, "line 2")
+
+		So(check("xml", `<a><b></a>`), ShouldBeLikeError, api.ErrMalformedBody)
+		So(check("xml", `<a>`), ShouldBeLikeError, api.ErrMalformedBody)
+		So(check("html", `<div></span></div>`), ShouldBeLikeError, api.ErrMalformedBody)
+		So(check("json", ""), ShouldBeLikeError, api.ErrNoBody)
+
+		Convey("JSON assertions should report malformed body", func() {
+			response := api.NewResponse(http.StatusOK, []byte(`{"a":`), nil, http.Header{})
+
+			_, err := response.RetrieveJSON("a")
+
+			So(err, ShouldBeLikeError, api.ErrMalformedBody)
+		})
+	})
+}
+
+func TestUnit_Response_IsValidUTF8(t *testing.T) {
+	Convey("When I check body encoding", t, func() {
+		So(api.NewResponse(http.StatusOK, []byte("héllo ✓"), nil, nil).IsValidUTF8(), ShouldBeNil)
+
+		err := api.NewResponse(http.StatusOK, []byte("h\xe9llo"), nil, nil).IsValidUTF8()
+
+		So(err, ShouldBeLikeError, api.ErrInvalidUTF8)
+		So(err.Error(), ShouldContainSubstring, "offset 1")
+	})
+}
@@ -0,0 +1,26 @@
+package api
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/elmagician/kactus/internal/types"
+)
+
+// ConvertValue converts a value retrieved from a response using a type hint
+// (int, float, bool, uuid, string...). Numbers are formatted without exponent
+// so large JSON numbers still convert to int.
+func ConvertValue(value interface{}, kind string) (interface{}, error) {
+	var formatted string
+
+	switch v := value.(type) {
+	case float64:
+		formatted = strconv.FormatFloat(v, 'f', -1, 64)
+	case float32:
+		formatted = strconv.FormatFloat(float64(v), 'f', -1, 32)
+	default:
+		formatted = fmt.Sprint(value)
+	}
+
+	return types.ToInterface(formatted + "((" + kind + "))")
+}
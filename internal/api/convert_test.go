@@ -0,0 +1,48 @@
+package api_test
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/elmagician/kactus/internal/api"
+)
+
+func TestUnit_ConvertValue(t *testing.T) {
+	Convey("Given values retrieved from a response", t, func() {
+		Convey("should convert large JSON numbers to int", func() {
+			value, err := api.ConvertValue(1234567.0, "int")
+			So(err, ShouldBeNil)
+			So(value, ShouldEqual, int64(1234567))
+
+			value, err = api.ConvertValue(9007199254740991.0, "int")
+			So(err, ShouldBeNil)
+			So(value, ShouldEqual, int64(9007199254740991))
+		})
+
+		Convey("should convert numbers to float and string", func() {
+			value, err := api.ConvertValue(1.5e7, "float")
+			So(err, ShouldBeNil)
+			So(value, ShouldEqual, 1.5e7)
+
+			value, err = api.ConvertValue(2500000.0, "string")
+			So(err, ShouldBeNil)
+			So(value, ShouldEqual, "2500000")
+		})
+
+		Convey("should convert strings", func() {
+			value, err := api.ConvertValue("42", "int")
+			So(err, ShouldBeNil)
+			So(value, ShouldEqual, int64(42))
+
+			value, err = api.ConvertValue("true", "bool")
+			So(err, ShouldBeNil)
+			So(value, ShouldEqual, true)
+		})
+
+		Convey("should fail on unconvertible values", func() {
+			_, err := api.ConvertValue(1.5, "int")
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
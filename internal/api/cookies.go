@@ -0,0 +1,113 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/cucumber/godog"
+
+	"github.com/elmagician/kactus/internal"
+	match "github.com/elmagician/kactus/internal/matchers"
+)
+
+// ErrMissingCookie is thrown when response does not set an expected cookie.
+var ErrMissingCookie = errors.New("response does not set cookie")
+
+// CookieAttribute retrieves cookie attribute as a string. Supported attributes
+// are value, domain, path, expires (HTTP date, empty for session cookies),
+// max-age, same-site (Lax, Strict, None or empty), secure and http-only.
+// `-`, `_` and case are ignored in attribute names.
+func CookieAttribute(cookie *http.Cookie, attribute string) (string, error) {
+	switch strings.NewReplacer("-", "", "_", "", " ", "").Replace(strings.ToLower(attribute)) {
+	case "", "value":
+		return cookie.Value, nil
+	case "domain":
+		return cookie.Domain, nil
+	case "path":
+		return cookie.Path, nil
+	case "expires":
+		if cookie.Expires.IsZero() {
+			return "", nil
+		}
+
+		return cookie.Expires.UTC().Format(http.TimeFormat), nil
+	case "maxage":
+		return strconv.Itoa(cookie.MaxAge), nil
+	case "samesite":
+		return sameSite(cookie.SameSite), nil
+	case "secure":
+		return strconv.FormatBool(cookie.Secure), nil
+	case "httponly":
+		return strconv.FormatBool(cookie.HttpOnly), nil
+	default:
+		return "", fmt.Errorf("%w: unknown cookie attribute %s", ErrInvalidOption, attribute)
+	}
+}
+
+// RetrieveCookieAttribute retrieves attribute of a cookie set by response.
+func (r Response) RetrieveCookieAttribute(name, attribute string) (string, error) {
+	cookie := r.GetCookie(name)
+	if cookie == nil {
+		return "", fmt.Errorf("%w: %s", ErrMissingCookie, name)
+	}
+
+	return CookieAttribute(cookie, attribute)
+}
+
+// CookiesMatch asserts cookies set by response match a table:
+//
+//	| cookie  | attribute | matcher | value  |
+//	| session | value     | defined |        |
+//	| session | same-site | equal   | Strict |
+//	| session | max-age   | equal   | 3600   |
+//
+// attribute defaults to value and matcher to equal.
+func (r Response) CookiesMatch(expected *godog.Table) error {
+	head := expected.Rows[0].Cells
+
+	for i := 1; i < len(expected.Rows); i++ {
+		var name, attribute, matcher, value string
+
+		for n, cell := range expected.Rows[i].Cells {
+			switch head[n].Value {
+			case "cookie", "name":
+				name = cell.Value
+			case "attribute":
+				attribute = cell.Value
+			case matcherHeader:
+				matcher = cell.Value
+			case valueHeader:
+				value = cell.Value
+			default:
+				return fmt.Errorf("%w %s", internal.ErrUnexpectedColumn, head[n].Value)
+			}
+		}
+
+		actual, err := r.RetrieveCookieAttribute(name, attribute)
+		if err != nil {
+			return err
+		}
+
+		if err := match.Assert(matcher, actual, value); err != nil {
+			return fmt.Errorf("cookie %s %s: %w", name, attribute, err)
+		}
+	}
+
+	return nil
+}
+
+func sameSite(mode http.SameSite) string {
+	switch mode {
+	case http.SameSiteLaxMode:
+		return "Lax"
+	case http.SameSiteStrictMode:
+		return "Strict"
+	case http.SameSiteNoneMode:
+		return "None"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,75 @@
+package api_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/elmagician/kactus/internal"
+	"github.com/elmagician/kactus/internal/api"
+	match "github.com/elmagician/kactus/internal/matchers"
+	. "github.com/elmagician/kactus/internal/test"
+)
+
+func TestUnit_Response_Cookies(t *testing.T) {
+	Convey("Given a response setting cookies", t, func() {
+		expires := time.Date(2030, time.January, 2, 3, 4, 5, 0, time.UTC)
+		response := api.NewResponse(http.StatusOK, nil, []*http.Cookie{
+			{
+				Name: "session", Value: "abc", Path: "/app", Domain: "example.test", MaxAge: 3600,
+				Secure: true, HttpOnly: true, SameSite: http.SameSiteStrictMode, Expires: expires,
+			},
+			{Name: "theme", Value: "dark"},
+		}, http.Header{})
+
+		Convey("should retrieve attributes", func() {
+			for attribute, expected := range map[string]string{
+				"value":     "abc",
+				"path":      "/app",
+				"domain":    "example.test",
+				"max-age":   "3600",
+				"same-site": "Strict",
+				"SameSite":  "Strict",
+				"secure":    "true",
+				"http_only": "true",
+				"expires":   "Wed, 02 Jan 2030 03:04:05 GMT",
+			} {
+				value, err := response.RetrieveCookieAttribute("session", attribute)
+				So(err, ShouldBeNil)
+				So(value, ShouldEqual, expected)
+			}
+
+			value, err := response.RetrieveCookieAttribute("theme", "expires")
+			So(err, ShouldBeNil)
+			So(value, ShouldBeEmpty)
+
+			_, err = response.RetrieveCookieAttribute("theme", "priority")
+			So(err, ShouldBeLikeError, api.ErrInvalidOption)
+
+			_, err = response.RetrieveCookieAttribute("missing", "value")
+			So(err, ShouldBeLikeError, api.ErrMissingCookie)
+		})
+
+		Convey("should match a cookie table", func() {
+			So(response.CookiesMatch(NewTable(
+				[]string{"cookie", "attribute", "matcher", "value"},
+				[]string{"session", "", "", "abc"},
+				[]string{"session", "same-site", "equal", "Strict"},
+				[]string{"session", "expires", "contain", "2030"},
+				[]string{"theme", "secure", "", "false"},
+			)), ShouldBeNil)
+
+			So(response.CookiesMatch(NewTable(
+				[]string{"cookie", "attribute", "value"},
+				[]string{"theme", "same-site", "Lax"},
+			)), ShouldBeLikeError, match.ErrUnmatched)
+
+			So(response.CookiesMatch(NewTable(
+				[]string{"cookie", "flag"},
+				[]string{"theme", "x"},
+			)), ShouldBeLikeError, internal.ErrUnexpectedColumn)
+		})
+	})
+}
@@ -0,0 +1,190 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CORS headers.
+const (
+	allowOriginHeader      = "Access-Control-Allow-Origin"
+	allowMethodsHeader     = "Access-Control-Allow-Methods"
+	allowHeadersHeader     = "Access-Control-Allow-Headers"
+	allowCredentialsHeader = "Access-Control-Allow-Credentials"
+	exposeHeadersHeader    = "Access-Control-Expose-Headers"
+	maxAgeHeader           = "Access-Control-Max-Age"
+	corsHeaderPrefix       = "Access-Control-"
+)
+
+// ErrCORS is thrown when a response does not follow expected CORS policy.
+var ErrCORS = errors.New("cors policy does not match expected")
+
+var (
+	// safelistedMethods are allowed by browsers without Access-Control-Allow-Methods.
+	safelistedMethods = map[string]bool{http.MethodGet: true, http.MethodHead: true, http.MethodPost: true}
+	// safelistedHeaders are allowed by browsers without Access-Control-Allow-Headers.
+	safelistedHeaders = map[string]bool{"accept": true, "accept-language": true, "content-language": true}
+)
+
+// Preflight emits a CORS preflight OPTIONS request to endpoint for origin
+// requesting method and headers. Response is stored as client Response.
+func (cli *Client) Preflight(endpoint, origin, method string, headers []string) error {
+	req := PrepareRequest(false).
+		SetMethod(http.MethodOptions).
+		SetEndpoint(endpoint).
+		AddHeader("Origin", origin).
+		AddHeader("Access-Control-Request-Method", strings.ToUpper(method))
+
+	if len(headers) > 0 {
+		req = req.AddHeader("Access-Control-Request-Headers", strings.Join(headers, ", "))
+	}
+
+	return cli.EmitRequest(req)
+}
+
+// NonSafelisted filters out CORS-safelisted method and headers as a server
+// does not need to allow them explicitly. Method is empty when safelisted.
+func NonSafelisted(method string, headers []string) (string, []string) {
+	if safelistedMethods[strings.ToUpper(method)] {
+		method = ""
+	}
+
+	var required []string
+
+	for _, header := range headers {
+		if !safelistedHeaders[strings.ToLower(strings.TrimSpace(header))] {
+			required = append(required, header)
+		}
+	}
+
+	return method, required
+}
+
+// CORSAllows asserts response CORS headers allow expected options:
+//
+//	| key            | value                 |
+//	| origin         | https://app.test      |
+//	| methods        | GET, POST             |
+//	| headers        | Authorization         |
+//	| credentials    | true                  |
+//	| max_age        | 600 (minimum seconds) |
+//	| expose_headers | X-Request-Id          |
+//
+// Wildcard `*` answers allow any origin, method or header unless credentials
+// are expected: browsers reject wildcards on credentialed requests so they
+// are reported. Every violation is reported.
+func (r Response) CORSAllows(expected map[string]string) error {
+	var violations []string
+
+	credentials, _ := strconv.ParseBool(expected["credentials"])
+	wildcard := !credentials
+
+	for key, value := range expected {
+		var violation string
+
+		switch key {
+		case "origin":
+			got := r.Headers.Get(allowOriginHeader)
+
+			switch {
+			case got == "*" && !wildcard:
+				violation = fmt.Sprintf("%s wildcard is not allowed with credentials", allowOriginHeader)
+			case got != value && got != "*":
+				violation = fmt.Sprintf("%s is %q, expected %q", allowOriginHeader, got, value)
+			}
+		case "methods":
+			violation = missingTokens(r.Headers, allowMethodsHeader, value, wildcard)
+		case "headers":
+			violation = missingTokens(r.Headers, allowHeadersHeader, value, wildcard)
+		case "expose_headers":
+			violation = missingTokens(r.Headers, exposeHeadersHeader, value, wildcard)
+		case "credentials":
+			want, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("%w: credentials %s", ErrInvalidOption, value)
+			}
+
+			if got := r.Headers.Get(allowCredentialsHeader) == "true"; got != want {
+				violation = fmt.Sprintf("%s should be %t", allowCredentialsHeader, want)
+			}
+		case "max_age", "max-age":
+			minimum, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("%w: max_age %s", ErrInvalidOption, value)
+			}
+
+			if got, err := strconv.Atoi(r.Headers.Get(maxAgeHeader)); err != nil || got < minimum {
+				violation = fmt.Sprintf("%s is %q, expected at least %d", maxAgeHeader, r.Headers.Get(maxAgeHeader), minimum)
+			}
+		default:
+			return fmt.Errorf("%w: unknown cors option %s", ErrInvalidOption, key)
+		}
+
+		if violation != "" {
+			violations = append(violations, violation)
+		}
+	}
+
+	return corsError(violations)
+}
+
+// HasNoCORSHeaders asserts response does not grant any CORS access.
+func (r Response) HasNoCORSHeaders() error {
+	var violations []string
+
+	for name := range r.Headers {
+		if strings.HasPrefix(name, corsHeaderPrefix) {
+			violations = append(violations, fmt.Sprintf("unexpected %s: %s", name, r.Headers.Get(name)))
+		}
+	}
+
+	return corsError(violations)
+}
+
+// missingTokens describes expected comma separated tokens absent from header.
+// A `*` token allows any token when wildcard is allowed and is reported otherwise.
+func missingTokens(headers http.Header, name, expected string, wildcard bool) string {
+	allowed := make(map[string]bool)
+
+	for _, line := range headers.Values(name) {
+		for _, token := range strings.Split(line, ",") {
+			allowed[strings.ToLower(strings.TrimSpace(token))] = true
+		}
+	}
+
+	if allowed["*"] {
+		if !wildcard {
+			return fmt.Sprintf("%s wildcard is not allowed with credentials", name)
+		}
+
+		return ""
+	}
+
+	var missing []string
+
+	for _, token := range strings.Split(expected, ",") {
+		if token = strings.TrimSpace(token); token != "" && !allowed[strings.ToLower(token)] {
+			missing = append(missing, token)
+		}
+	}
+
+	if len(missing) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("%s %q does not allow %s", name, headers.Get(name), strings.Join(missing, ", "))
+}
+
+func corsError(violations []string) error {
+	if len(violations) == 0 {
+		return nil
+	}
+
+	sort.Strings(violations)
+
+	return fmt.Errorf("%w:\n  - %s", ErrCORS, strings.Join(violations, "\n  - "))
+}
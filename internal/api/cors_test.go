@@ -0,0 +1,122 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/elmagician/kactus/internal/api"
+	. "github.com/elmagician/kactus/internal/test"
+)
+
+func TestUnit_Client_Preflight(t *testing.T) {
+	Convey("Given a CORS enabled endpoint", t, func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodOptions || r.Header.Get("Origin") != "https://app.test" {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			w.Header().Set("Access-Control-Allow-Origin", r.Header.Get("Origin"))
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST")
+			w.Header().Set("Access-Control-Allow-Headers", "authorization, content-type")
+			w.Header().Set("Access-Control-Allow-Credentials", "true")
+			w.Header().Set("Access-Control-Max-Age", "600")
+			w.Header().Set("X-Requested-Method", r.Header.Get("Access-Control-Request-Method"))
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		cli, err := api.NewClient(&http.Client{})
+		So(err, ShouldBeNil)
+
+		Convey("should allow configured origin", func() {
+			So(cli.Preflight(server.URL, "https://app.test", "post", []string{"Authorization"}), ShouldBeNil)
+			So(cli.Response.Headers.Get("X-Requested-Method"), ShouldEqual, "POST")
+
+			So(cli.Response.CORSAllows(map[string]string{
+				"origin":      "https://app.test",
+				"methods":     "POST",
+				"headers":     "Authorization, Content-Type",
+				"credentials": "true",
+				"max_age":     "300",
+			}), ShouldBeNil)
+			So(cli.Response.HasNoCORSHeaders(), ShouldBeLikeError, api.ErrCORS)
+
+			Convey("and report every violation", func() {
+				err := cli.Response.CORSAllows(map[string]string{
+					"methods":     "DELETE",
+					"headers":     "X-Custom",
+					"credentials": "false",
+					"max_age":     "3600",
+				})
+
+				So(err, ShouldBeLikeError, api.ErrCORS)
+				So(err.Error(), ShouldContainSubstring, "DELETE")
+				So(err.Error(), ShouldContainSubstring, "X-Custom")
+				So(err.Error(), ShouldContainSubstring, "Credentials")
+				So(err.Error(), ShouldContainSubstring, "at least 3600")
+			})
+		})
+
+		Convey("should reject other origins", func() {
+			So(cli.Preflight(server.URL, "https://evil.test", "GET", nil), ShouldBeNil)
+			So(cli.Response.HasNoCORSHeaders(), ShouldBeNil)
+			So(cli.Response.CORSAllows(map[string]string{"origin": "https://evil.test"}), ShouldBeLikeError, api.ErrCORS)
+		})
+
+		Convey("should reject unknown options", func() {
+			So(cli.Preflight(server.URL, "https://app.test", "GET", nil), ShouldBeNil)
+			So(cli.Response.CORSAllows(map[string]string{"referrer": "x"}), ShouldBeLikeError, api.ErrInvalidOption)
+		})
+	})
+}
+
+func TestUnit_Response_CORSAllows_Wildcard(t *testing.T) {
+	Convey("Given a response allowing any origin, method and header", t, func() {
+		response := api.NewResponse(http.StatusNoContent, nil, nil, http.Header{
+			"Access-Control-Allow-Origin":      {"*"},
+			"Access-Control-Allow-Methods":     {"*"},
+			"Access-Control-Allow-Headers":     {"*"},
+			"Access-Control-Allow-Credentials": {"true"},
+		})
+
+		expected := map[string]string{
+			"origin":  "https://app.test",
+			"methods": "POST",
+			"headers": "Authorization",
+		}
+
+		Convey("should allow requests without credentials", func() {
+			So(response.CORSAllows(expected), ShouldBeNil)
+
+			expected["credentials"] = "false"
+			response.Headers.Del("Access-Control-Allow-Credentials")
+			So(response.CORSAllows(expected), ShouldBeNil)
+		})
+
+		Convey("should report wildcards when credentials are expected", func() {
+			expected["credentials"] = "true"
+
+			err := response.CORSAllows(expected)
+			So(err, ShouldBeLikeError, api.ErrCORS)
+			So(err.Error(), ShouldContainSubstring, "Allow-Origin wildcard")
+			So(err.Error(), ShouldContainSubstring, "Allow-Methods wildcard")
+			So(err.Error(), ShouldContainSubstring, "Allow-Headers wildcard")
+		})
+	})
+}
+
+func TestUnit_NonSafelisted(t *testing.T) {
+	Convey("When I filter CORS-safelisted method and headers", t, func() {
+		method, headers := api.NonSafelisted("get", []string{"Accept", "Content-Language", "Authorization"})
+		So(method, ShouldBeEmpty)
+		So(headers, ShouldResemble, []string{"Authorization"})
+
+		method, headers = api.NonSafelisted("DELETE", []string{"accept-language"})
+		So(method, ShouldEqual, "DELETE")
+		So(headers, ShouldBeEmpty)
+	})
+}
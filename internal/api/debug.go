@@ -3,22 +3,76 @@ package api
 import (
 	"net/http"
 	"net/http/httptrace"
+	"sync"
 
 	"go.uber.org/zap"
 )
 
 type debugTransport struct {
+	mu      sync.Mutex
 	current *http.Request
+
+	// base emits requests. http.DefaultTransport is used when nil.
+	base http.RoundTripper
+	// sockets emit requests targeting an Unix socket, by socket path.
+	sockets map[string]*http.Transport
 }
 
 func (dt *debugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	dt.mu.Lock()
 	dt.current = req
-	return http.DefaultTransport.RoundTrip(req)
+	base := dt.base
+
+	if route, ok := req.Context().Value(unixSocketKey{}).(unixRoute); ok && route.host == req.URL.Host {
+		if dt.sockets == nil {
+			dt.sockets = make(map[string]*http.Transport)
+		}
+
+		if dt.sockets[route.socket] == nil {
+			dt.sockets[route.socket] = unixTransport(route.socket)
+		}
+
+		base = dt.sockets[route.socket]
+	}
+
+	dt.mu.Unlock()
+
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return base.RoundTrip(req)
+}
+
+func (dt *debugTransport) setBase(base http.RoundTripper) {
+	dt.mu.Lock()
+	defer dt.mu.Unlock()
+
+	if closer, ok := dt.base.(interface{ CloseIdleConnections() }); ok {
+		closer.CloseIdleConnections()
+	}
+
+	dt.base = base
+}
+
+// closeSockets closes Unix socket transports idle connections and forgets them.
+func (dt *debugTransport) closeSockets() {
+	dt.mu.Lock()
+	defer dt.mu.Unlock()
+
+	for _, transport := range dt.sockets {
+		transport.CloseIdleConnections()
+	}
+
+	dt.sockets = nil
 }
 
 // GotConn prints whether the connection has been used previously
 // for the current request.
 func (dt *debugTransport) GotConn(info httptrace.GotConnInfo) {
+	dt.mu.Lock()
+	defer dt.mu.Unlock()
+
 	log.Debug(
 		"Connection reused",
 		zap.Any("url", dt.current.URL),
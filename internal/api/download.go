@@ -0,0 +1,302 @@
+package api
+
+import (
+	"bytes"
+	"crypto/md5" // nolint: gosec
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// sniffLength is the number of leading body bytes kept to detect file type.
+const sniffLength = 512
+
+var (
+	// ErrChecksum is thrown when body checksum does not match expected.
+	ErrChecksum = errors.New("checksum does not match expected")
+	// ErrSize is thrown when body size does not match expected.
+	ErrSize = errors.New("size does not match expected")
+	// ErrFileType is thrown when body file type does not match expected.
+	ErrFileType = errors.New("file type does not match expected")
+	// ErrPartialContent is thrown when response is not the expected partial content.
+	ErrPartialContent = errors.New("partial content does not match expected")
+	// ErrNoDownload is thrown when an assertion does not apply to the way
+	// response body was received: streamed to a file or buffered in memory.
+	ErrNoDownload = errors.New("response body is not available this way")
+)
+
+// magicNumber identifies a file type by bytes found at offset.
+type magicNumber struct {
+	offset int
+	magic  string
+}
+
+// magicNumbers maps file type short names to their signature.
+var magicNumbers = map[string]magicNumber{
+	"pdf":     {0, "%PDF-"},
+	"png":     {0, "\x89PNG\r\n\x1a\n"},
+	"jpeg":    {0, "\xff\xd8\xff"},
+	"jpg":     {0, "\xff\xd8\xff"},
+	"gif":     {0, "GIF8"},
+	"webp":    {8, "WEBP"},
+	"zip":     {0, "PK\x03\x04"},
+	"gzip":    {0, "\x1f\x8b"},
+	"zstd":    {0, "\x28\xb5\x2f\xfd"},
+	"bzip2":   {0, "BZh"},
+	"7z":      {0, "7z\xbc\xaf\x27\x1c"},
+	"tar":     {257, "ustar"},
+	"elf":     {0, "\x7fELF"},
+	"wasm":    {0, "\x00asm"},
+	"parquet": {0, "PAR1"},
+	"sqlite":  {0, "SQLite format 3\x00"},
+}
+
+// Download describes a response body streamed to a file.
+// File holds decoded body, like Body of buffered responses.
+type Download struct {
+	Path   string
+	Size   int64
+	SHA256 string
+	MD5    string
+
+	// head is the beginning of body used to detect file type.
+	head []byte
+}
+
+// SetStreaming enables or disables streaming response bodies to temporary
+// files instead of buffering them in memory. Streamed responses have no Body
+// but a Download. Files are removed and streaming disabled on Reset.
+func (cli *Client) SetStreaming(enabled bool) {
+	cli.streaming = enabled
+}
+
+// streamBody writes body to a temporary file while hashing it.
+func (cli *Client) streamBody(body io.Reader) (*Download, error) {
+	file, err := os.CreateTemp("", "kactus-download-*")
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	cli.downloads = append(cli.downloads, file.Name())
+
+	sha, md := sha256.New(), md5.New() // nolint: gosec
+	head := &headWriter{limit: sniffLength}
+
+	size, err := io.Copy(io.MultiWriter(file, sha, md, head), body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Download{
+		Path:   file.Name(),
+		Size:   size,
+		SHA256: hex.EncodeToString(sha.Sum(nil)),
+		MD5:    hex.EncodeToString(md.Sum(nil)),
+		head:   head.Bytes(),
+	}, nil
+}
+
+// removeDownloads deletes files created by streamed responses.
+func (cli *Client) removeDownloads() {
+	for _, path := range cli.downloads {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			log.Warn("could not remove downloaded file", zap.String("path", path), zap.Error(err))
+		}
+	}
+
+	cli.downloads = nil
+}
+
+// DownloadPath returns file holding streamed response body.
+func (r Response) DownloadPath() (string, error) {
+	if r.Download == nil {
+		return "", fmt.Errorf("%w: body was buffered in memory", ErrNoDownload)
+	}
+
+	return r.Download.Path, nil
+}
+
+// streamedError returns ErrNoDownload when body was streamed to a file
+// instead of being buffered.
+func (r Response) streamedError() error {
+	if r.Download != nil {
+		return fmt.Errorf("%w: body was streamed to %s", ErrNoDownload, r.Download.Path)
+	}
+
+	return nil
+}
+
+// bodyError returns why buffered body cannot be asserted, if any.
+func (r Response) bodyError() error {
+	if err := r.streamedError(); err != nil {
+		return err
+	}
+
+	if r.HasEmptyBody() {
+		return ErrNoBody
+	}
+
+	return nil
+}
+
+// Size returns body size, streamed or buffered.
+func (r Response) Size() int64 {
+	if r.Download != nil {
+		return r.Download.Size
+	}
+
+	return int64(len(r.Body))
+}
+
+// Checksum returns hex encoded sha256 or md5 of body, streamed or buffered.
+func (r Response) Checksum(algorithm string) (string, error) {
+	var hasher hash.Hash
+
+	switch strings.ToLower(strings.ReplaceAll(algorithm, "-", "")) {
+	case "sha256":
+		if r.Download != nil {
+			return r.Download.SHA256, nil
+		}
+
+		hasher = sha256.New()
+	case "md5":
+		if r.Download != nil {
+			return r.Download.MD5, nil
+		}
+
+		hasher = md5.New() // nolint: gosec
+	default:
+		return "", fmt.Errorf("%w: unsupported checksum %s", ErrInvalidOption, algorithm)
+	}
+
+	hasher.Write(r.Body) // nolint: errcheck
+
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// ChecksumIs asserts body checksum. Expected hex digest is case insensitive.
+func (r Response) ChecksumIs(algorithm, expected string) error {
+	actual, err := r.Checksum(algorithm)
+	if err != nil {
+		return err
+	}
+
+	if !strings.EqualFold(actual, strings.TrimSpace(expected)) {
+		return fmt.Errorf("%w: %s expected %s, got %s", ErrChecksum, algorithm, expected, actual)
+	}
+
+	return nil
+}
+
+// SizeIs asserts body size in bytes.
+func (r Response) SizeIs(expected int64) error {
+	if size := r.Size(); size != expected {
+		return fmt.Errorf("%w: expected %d bytes, got %d", ErrSize, expected, size)
+	}
+
+	return nil
+}
+
+// FileTypeIs asserts body file type detected from its magic number.
+// Expected is either a short name (pdf, png, jpeg, gif, webp, zip, gzip,
+// zstd, bzip2, 7z, tar, elf, wasm, parquet, sqlite) or a media type compared
+// to http.DetectContentType result.
+func (r Response) FileTypeIs(expected string) error {
+	head := r.Body
+	if r.Download != nil {
+		head = r.Download.head
+	}
+
+	if len(head) > sniffLength {
+		head = head[:sniffLength]
+	}
+
+	expected = strings.ToLower(strings.TrimSpace(expected))
+
+	if signature, ok := magicNumbers[expected]; ok {
+		end := signature.offset + len(signature.magic)
+		if len(head) >= end && string(head[signature.offset:end]) == signature.magic {
+			return nil
+		}
+
+		return fmt.Errorf("%w: expected %s, detected %s", ErrFileType, expected, http.DetectContentType(head))
+	}
+
+	detected, _, err := mime.ParseMediaType(http.DetectContentType(head))
+	if err != nil {
+		return err
+	}
+
+	if detected != expected {
+		return fmt.Errorf("%w: expected %s, detected %s", ErrFileType, expected, detected)
+	}
+
+	return nil
+}
+
+// SetRange requests a byte range, e.g. `0-99`, `100-` or `-50`.
+func (request RequestPreparation) SetRange(byteRange string) RequestPreparation {
+	request.Headers.Set("Range", "bytes="+strings.TrimPrefix(strings.TrimSpace(byteRange), "bytes="))
+	return request
+}
+
+// IsPartialContent asserts response is a 206 Partial Content holding bytes
+// first to last (inclusive) of a representation of total bytes. Total can be
+// `*` for unknown length or empty to skip its check.
+func (r Response) IsPartialContent(first, last int64, total string) error {
+	if r.Status != http.StatusPartialContent {
+		return fmt.Errorf("%w: expected status %d, got %d", ErrPartialContent, http.StatusPartialContent, r.Status)
+	}
+
+	contentRange := r.Headers.Get("Content-Range")
+
+	unit, spec, _ := strings.Cut(contentRange, " ")
+	rng, length, _ := strings.Cut(spec, "/")
+	start, end, _ := strings.Cut(rng, "-")
+
+	if unit != "bytes" || start != strconv.FormatInt(first, 10) || end != strconv.FormatInt(last, 10) ||
+		(total != "" && length != total) {
+		expected := fmt.Sprintf("bytes %d-%d/%s", first, last, total)
+		if total == "" {
+			expected = fmt.Sprintf("bytes %d-%d/<any>", first, last)
+		}
+
+		return fmt.Errorf("%w: expected Content-Range %s, got %q", ErrPartialContent, expected, contentRange)
+	}
+
+	if size := r.Size(); size != last-first+1 {
+		return fmt.Errorf("%w: expected %d bytes, got %d", ErrPartialContent, last-first+1, size)
+	}
+
+	return nil
+}
+
+// headWriter keeps the first limit bytes written.
+type headWriter struct {
+	bytes.Buffer
+	limit int
+}
+
+func (w *headWriter) Write(p []byte) (int, error) {
+	if remaining := w.limit - w.Len(); remaining > 0 {
+		if len(p) < remaining {
+			remaining = len(p)
+		}
+
+		w.Buffer.Write(p[:remaining])
+	}
+
+	return len(p), nil
+}
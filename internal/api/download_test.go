@@ -0,0 +1,169 @@
+package api_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cucumber/godog"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/elmagician/kactus/internal/api"
+	. "github.com/elmagician/kactus/internal/test"
+)
+
+func TestUnit_Client_Downloads(t *testing.T) {
+	Convey("Given a file server", t, func() {
+		content := append([]byte("%PDF-1.7\n"), bytes.Repeat([]byte("kactus"), 2000)...)
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.ServeContent(w, r, "doc.pdf", time.Time{}, bytes.NewReader(content))
+		}))
+		defer server.Close()
+
+		cli, err := api.NewClient(&http.Client{})
+		So(err, ShouldBeNil)
+
+		get := api.PrepareRequest(false).SetMethod(http.MethodGet).SetEndpoint(server.URL)
+
+		Convey("should stream body to a file", func() {
+			cli.SetStreaming(true)
+			So(cli.EmitRequest(get), ShouldBeNil)
+			So(cli.Response.Body, ShouldBeEmpty)
+
+			path, err := cli.Response.DownloadPath()
+			So(err, ShouldBeNil)
+
+			written, err := os.ReadFile(path)
+			So(err, ShouldBeNil)
+			So(written, ShouldResemble, content)
+
+			So(cli.Response.SizeIs(int64(len(content))), ShouldBeNil)
+			So(cli.Response.SizeIs(1), ShouldBeLikeError, api.ErrSize)
+			So(cli.Response.FileTypeIs("pdf"), ShouldBeNil)
+			So(cli.Response.FileTypeIs("application/pdf"), ShouldBeNil)
+			So(cli.Response.FileTypeIs("png"), ShouldBeLikeError, api.ErrFileType)
+
+			Convey("and remove it on reset", func() {
+				cli.Reset()
+
+				_, err := os.Stat(path)
+				So(os.IsNotExist(err), ShouldBeTrue)
+
+				So(cli.EmitRequest(get), ShouldBeNil)
+				So(cli.Response.Body, ShouldResemble, content)
+
+				_, err = cli.Response.DownloadPath()
+				So(err, ShouldBeLikeError, api.ErrNoDownload)
+			})
+		})
+
+		Convey("should compute same checksums streamed or buffered", func() {
+			So(cli.EmitRequest(get), ShouldBeNil)
+			buffered := *cli.Response
+
+			cli.SetStreaming(true)
+			So(cli.EmitRequest(get), ShouldBeNil)
+
+			for _, algorithm := range []string{"sha256", "md5"} {
+				expected, err := buffered.Checksum(algorithm)
+				So(err, ShouldBeNil)
+				So(cli.Response.ChecksumIs(algorithm, strings.ToUpper(expected)), ShouldBeNil)
+			}
+
+			So(cli.Response.ChecksumIs("sha256", "00"), ShouldBeLikeError, api.ErrChecksum)
+			So(cli.Response.ChecksumIs("crc32", "00"), ShouldBeLikeError, api.ErrInvalidOption)
+		})
+
+		Convey("should assert ranges", func() {
+			So(cli.EmitRequest(get.SetRange("0-99")), ShouldBeNil)
+			So(cli.Response.IsPartialContent(0, 99, ""), ShouldBeNil)
+			So(cli.Response.IsPartialContent(0, 99, "12009"), ShouldBeNil)
+			So(cli.Response.IsPartialContent(0, 99, "*"), ShouldBeLikeError, api.ErrPartialContent)
+			So(cli.Response.IsPartialContent(1, 100, ""), ShouldBeLikeError, api.ErrPartialContent)
+
+			So(cli.EmitRequest(get.ResetHeader().SetRange("bytes=-9")), ShouldBeNil)
+			So(cli.Response.IsPartialContent(12000, 12008, "12009"), ShouldBeNil)
+
+			So(cli.EmitRequest(get.ResetHeader()), ShouldBeNil)
+			So(cli.Response.IsPartialContent(0, 12008, ""), ShouldBeLikeError, api.ErrPartialContent)
+		})
+	})
+}
+
+func TestUnit_Client_EncodedDownloads(t *testing.T) {
+	Convey("Given a server sending encoded JSON", t, func() {
+		content := []byte(`{"name": "kactus"}`)
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			encoded := content
+			for _, encoding := range strings.Split(r.URL.Query().Get("encoding"), ",") {
+				encoded, _ = api.Compress(encoding, encoded) // nolint: errcheck
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Content-Encoding", r.URL.Query().Get("encoding"))
+			w.Write(encoded) // nolint: errcheck
+		}))
+		defer server.Close()
+
+		cli, err := api.NewClient(&http.Client{})
+		So(err, ShouldBeNil)
+
+		cli.SetStreaming(true)
+
+		for _, encoding := range []string{"br", "zstd", "deflate", "gzip, zstd"} {
+			encoding := encoding
+
+			Convey("should stream decoded "+encoding+" body", func() {
+				So(cli.EmitRequest(api.PrepareRequest(false).SetMethod(http.MethodGet).
+					SetEndpoint(server.URL+"?encoding="+url.QueryEscape(encoding))), ShouldBeNil)
+
+				path, err := cli.Response.DownloadPath()
+				So(err, ShouldBeNil)
+
+				written, err := os.ReadFile(path)
+				So(err, ShouldBeNil)
+				So(string(written), ShouldEqual, string(content))
+				So(cli.Response.SizeIs(int64(len(content))), ShouldBeNil)
+				So(cli.Response.FileTypeIs("text/plain"), ShouldBeNil)
+			})
+		}
+
+		Convey("body assertions should fail on streamed body", func() {
+			So(cli.EmitRequest(api.PrepareRequest(false).SetMethod(http.MethodGet).
+				SetEndpoint(server.URL+"?encoding=gzip")), ShouldBeNil)
+
+			_, err := cli.Response.RetrieveJSON("name")
+			So(err, ShouldBeLikeError, api.ErrNoDownload)
+
+			So(cli.Response.JSONContains(false, NewTable([]string{"field", "value"}, []string{"name", "kactus"})), ShouldBeLikeError, api.ErrNoDownload)
+			So(cli.Response.IsWellFormed("json"), ShouldBeLikeError, api.ErrNoDownload)
+			So(cli.Response.JSONResemble(&godog.DocString{Content: string(content)}), ShouldBeLikeError, api.ErrNoDownload)
+		})
+	})
+}
+
+func TestUnit_Response_FileTypeIs(t *testing.T) {
+	Convey("Given binary bodies", t, func() {
+		tar := make([]byte, 512)
+		copy(tar[257:], "ustar")
+
+		for kind, body := range map[string][]byte{
+			"png":  []byte("\x89PNG\r\n\x1a\n...."),
+			"gzip": {0x1f, 0x8b, 0x08},
+			"zip":  []byte("PK\x03\x04...."),
+			"webp": []byte("RIFF\x00\x00\x00\x00WEBPVP8 "),
+			"tar":  tar,
+		} {
+			response := api.NewResponse(http.StatusOK, body, nil, http.Header{})
+			So(response.FileTypeIs(kind), ShouldBeNil)
+			So(response.FileTypeIs("pdf"), ShouldBeLikeError, api.ErrFileType)
+		}
+	})
+}
@@ -0,0 +1,22 @@
+package api
+
+import (
+	"net/http"
+	"time"
+)
+
+// SetSignerClock freezes AWSV4Signer clock for tests.
+func SetSignerClock(signer *AWSV4Signer, now time.Time) {
+	signer.now = func() time.Time { return now }
+}
+
+// SessionCookies returns cookies saved in session name for tests.
+func SessionCookies(cli *Client, name string) []*http.Cookie {
+	var cookies []*http.Cookie
+
+	for _, entry := range cli.sessions[name] {
+		cookies = append(cookies, entry.cookie)
+	}
+
+	return cookies
+}
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	match "github.com/elmagician/kactus/internal/matchers"
+)
+
+// Quantifiers for multi valued header assertions.
+const (
+	EveryValue = "every"
+	AnyValue   = "any"
+	NoValue    = "no"
+)
+
+var (
+	// ErrMissingHeader is thrown when an expected header is absent.
+	ErrMissingHeader = errors.New("missing header")
+	// ErrUnexpectedHeader is thrown when a header expected to be absent exists.
+	ErrUnexpectedHeader = errors.New("unexpected header")
+)
+
+// headers whose values contain commas and cannot be split as lists.
+var unsplittableHeaders = map[string]bool{
+	"Set-Cookie": true, "Date": true, "Expires": true, "Last-Modified": true,
+	"If-Modified-Since": true, "If-Unmodified-Since": true,
+	"Www-Authenticate": true, "Proxy-Authenticate": true,
+}
+
+// HasHeader checks if response has header name, even empty.
+func (r Response) HasHeader(name string) bool {
+	_, ok := r.Headers[http.CanonicalHeaderKey(name)]
+	return ok
+}
+
+// HeaderValues returns every value of header name. Comma separated lists
+// (`Vary: Accept, Origin`) are split unless header values may contain
+// commas such as Set-Cookie or dates.
+func (r Response) HeaderValues(name string) []string {
+	name = http.CanonicalHeaderKey(name)
+	lines := r.Headers.Values(name)
+
+	if unsplittableHeaders[name] {
+		return lines
+	}
+
+	values := make([]string, 0, len(lines))
+
+	for _, line := range lines {
+		for _, value := range strings.Split(line, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+
+	return values
+}
+
+// HeaderValuesMatch asserts every, any or no value of header name
+// matches expected value using matcher. Any and no quantifiers also
+// match whole comma separated header lines.
+func (r Response) HeaderValuesMatch(name, quantifier, matcher, expected string) error {
+	if quantifier != EveryValue && quantifier != AnyValue && quantifier != NoValue {
+		return fmt.Errorf("%w: quantifier %s", ErrInvalidOption, quantifier)
+	}
+
+	values := r.HeaderValues(name)
+	if len(values) == 0 && quantifier != NoValue {
+		return fmt.Errorf("%w: %s", ErrMissingHeader, name)
+	}
+
+	if quantifier != EveryValue {
+		// whole header lines are candidates too so that a list matches as sent.
+		for _, line := range r.Headers.Values(name) {
+			if strings.Contains(line, ",") && !unsplittableHeaders[http.CanonicalHeaderKey(name)] {
+				values = append(values, line)
+			}
+		}
+	}
+
+	var mismatches []string
+
+	for _, value := range values {
+		err := match.Assert(matcher, value, expected)
+
+		switch {
+		case errors.Is(err, match.ErrUnmatched):
+			mismatches = append(mismatches, value)
+		case err != nil:
+			return err
+		case quantifier == AnyValue:
+			return nil
+		case quantifier == NoValue:
+			return fmt.Errorf("%w: %s value %q should not %s %s", ErrNoMatch, name, value, matcher, expected)
+		}
+	}
+
+	if quantifier == AnyValue || (quantifier == EveryValue && len(mismatches) > 0) {
+		return fmt.Errorf("%w: %s values %q should %s %s", ErrNoMatch, name, mismatches, matcher, expected)
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package api_test
+
+import (
+	"net/http"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/elmagician/kactus/internal/api"
+	. "github.com/elmagician/kactus/internal/test"
+)
+
+func TestUnit_Response_HeaderValues(t *testing.T) {
+	Convey("Given a response with multi valued headers", t, func() {
+		response := api.NewResponse(http.StatusOK, nil, nil, http.Header{
+			"Vary":          {"Accept, Origin", "Accept-Encoding"},
+			"Set-Cookie":    {"a=1; Expires=Wed, 21 Oct 2015 07:28:00 GMT; Secure", "b=2; Secure"},
+			"Cache-Control": {"no-store"},
+			"X-Empty":       {""},
+		})
+
+		Convey("should split lists but not cookies", func() {
+			So(response.HeaderValues("vary"), ShouldResemble, []string{"Accept", "Origin", "Accept-Encoding"})
+			So(response.HeaderValues("Set-Cookie"), ShouldHaveLength, 2)
+			So(response.HasHeader("x-empty"), ShouldBeTrue)
+			So(response.HasHeader("X-Missing"), ShouldBeFalse)
+		})
+
+		Convey("should match using quantifiers", func() {
+			So(response.HeaderValuesMatch("Set-Cookie", api.EveryValue, "contain", "Secure"), ShouldBeNil)
+			So(response.HeaderValuesMatch("Vary", api.AnyValue, "equal", "Origin"), ShouldBeNil)
+			So(response.HeaderValuesMatch("Vary", api.AnyValue, "equal", "Accept, Origin"), ShouldBeNil)
+			So(response.HeaderValuesMatch("Vary", api.NoValue, "equal", "Cookie"), ShouldBeNil)
+			So(response.HeaderValuesMatch("X-Missing", api.NoValue, "equal", "x"), ShouldBeNil)
+
+			So(response.HeaderValuesMatch("Vary", api.EveryValue, "match", "^Accept"), ShouldBeLikeError, api.ErrNoMatch)
+			So(response.HeaderValuesMatch("Vary", api.AnyValue, "equal", "Cookie"), ShouldBeLikeError, api.ErrNoMatch)
+			So(response.HeaderValuesMatch("Vary", api.NoValue, "equal", "Origin"), ShouldBeLikeError, api.ErrNoMatch)
+			So(response.HeaderValuesMatch("X-Missing", api.AnyValue, "equal", "x"), ShouldBeLikeError, api.ErrMissingHeader)
+			So(response.HeaderValuesMatch("Vary", "some", "equal", "x"), ShouldBeLikeError, api.ErrInvalidOption)
+		})
+
+		Convey("should match a header table", func() {
+			So(response.HeaderMatches(NewTable(
+				[]string{"key", "matcher", "value"},
+				[]string{"cache-control", "equal", "no-store"},
+				[]string{"Vary", "=", "Accept-Encoding"},
+			)), ShouldBeNil)
+
+			So(response.HeaderMatches(NewTable(
+				[]string{"header", "matcher", "value"},
+				[]string{"X-Missing", "defined", ""},
+			)), ShouldBeLikeError, api.ErrMissingHeader)
+		})
+	})
+}
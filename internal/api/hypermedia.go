@@ -0,0 +1,140 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrNoLink is thrown when response does not provide a link relation.
+var ErrNoLink = errors.New("link not found in response")
+
+// FindHypermediaLink returns target of a link relation provided by response,
+// looking for it in order in:
+//   - HAL `_links` or JSON:API `links` (root and `data` resource),
+//   - RFC 8288 Link headers,
+//   - links of a nested resource when rel is a `.` separated path such as
+//     `orders.0.self` or `author.related`. Resource is searched from body root,
+//     HAL `_embedded` and JSON:API `data` and `data.relationships`.
+//
+// Link objects are read from their href member. When a relation lists
+// many links, first one is used. Templated links are not expanded.
+func (r Response) FindHypermediaLink(rel string) (string, error) {
+	var body interface{}
+
+	if !r.HasEmptyBody() {
+		// bodies which are not json can still provide Link headers.
+		body, _ = r.Decode()
+	}
+
+	if href, ok := resourceLink(body, rel); ok {
+		return href, nil
+	}
+
+	if href, ok := FindLink(r.Headers, rel); ok {
+		return href, nil
+	}
+
+	if resource, linkRel, ok := cutLast(rel, "."); ok {
+		keys := strings.Split(resource, ".")
+
+		for _, prefix := range [][]string{nil, {"_embedded"}, {"data"}, {"data", "relationships"}} {
+			node, found := walkPath(body, append(prefix, keys...))
+			if !found {
+				continue
+			}
+
+			if href, ok := resourceLink(node, linkRel); ok {
+				return href, nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("%w: %s", ErrNoLink, rel)
+}
+
+// ResolveLink resolves a link relation provided by last response against
+// its URL. See Response.FindHypermediaLink.
+func (cli *Client) ResolveLink(rel string) (string, error) {
+	if cli.Response == nil {
+		return "", fmt.Errorf("%w: %s", ErrNoLink, rel)
+	}
+
+	href, err := cli.Response.FindHypermediaLink(rel)
+	if err != nil {
+		return "", err
+	}
+
+	return cli.ResolveURL(href)
+}
+
+// resourceLink finds rel in HAL `_links` or JSON:API `links` of resource.
+// JSON:API primary data links are searched as well.
+func resourceLink(resource interface{}, rel string) (string, bool) {
+	object, ok := resource.(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+
+	for _, member := range []string{"_links", "links"} {
+		if links, ok := object[member].(map[string]interface{}); ok {
+			if href, ok := linkHref(links[rel]); ok {
+				return href, true
+			}
+		}
+	}
+
+	return resourceLink(object["data"], rel)
+}
+
+// linkHref reads target of a link given as a string, an object
+// with an href member or an array of those.
+func linkHref(link interface{}) (string, bool) {
+	switch typed := link.(type) {
+	case string:
+		return typed, typed != ""
+	case map[string]interface{}:
+		href, ok := typed["href"].(string)
+		return href, ok && href != ""
+	case []interface{}:
+		if len(typed) > 0 {
+			return linkHref(typed[0])
+		}
+	}
+
+	return "", false
+}
+
+func walkPath(node interface{}, keys []string) (interface{}, bool) {
+	for _, key := range keys {
+		switch typed := node.(type) {
+		case map[string]interface{}:
+			value, ok := typed[key]
+			if !ok {
+				return nil, false
+			}
+
+			node = value
+		case []interface{}:
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len(typed) {
+				return nil, false
+			}
+
+			node = typed[index]
+		default:
+			return nil, false
+		}
+	}
+
+	return node, true
+}
+
+func cutLast(value, separator string) (before, after string, found bool) {
+	if i := strings.LastIndex(value, separator); i >= 0 {
+		return value[:i], value[i+len(separator):], true
+	}
+
+	return value, "", false
+}
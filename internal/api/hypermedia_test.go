@@ -0,0 +1,103 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/elmagician/kactus/internal/api"
+	. "github.com/elmagician/kactus/internal/test"
+)
+
+func TestUnit_Response_FindHypermediaLink(t *testing.T) {
+	Convey("Given hypermedia responses", t, func() {
+		json := func(body string, headers http.Header) *api.Response {
+			headers.Set("Content-Type", "application/json")
+			return api.NewResponse(http.StatusOK, []byte(body), nil, headers)
+		}
+
+		Convey("should find HAL links", func() {
+			response := json(`{
+				"_links": {"self": {"href": "/orders"}, "next": [{"href": "/orders?page=2"}, {"href": "/x"}]},
+				"_embedded": {"orders": [{"_links": {"self": {"href": "/orders/1"}}}]}
+			}`, http.Header{})
+
+			for rel, expected := range map[string]string{
+				"self":          "/orders",
+				"next":          "/orders?page=2",
+				"orders.0.self": "/orders/1",
+			} {
+				href, err := response.FindHypermediaLink(rel)
+				So(err, ShouldBeNil)
+				So(href, ShouldEqual, expected)
+			}
+		})
+
+		Convey("should find JSON:API links", func() {
+			response := json(`{
+				"links": {"next": "/articles?page[number]=2"},
+				"data": {
+					"links": {"self": "/articles/1"},
+					"relationships": {"author": {"links": {"related": "/articles/1/author"}}}
+				}
+			}`, http.Header{})
+
+			for rel, expected := range map[string]string{
+				"next":           "/articles?page[number]=2",
+				"self":           "/articles/1",
+				"author.related": "/articles/1/author",
+			} {
+				href, err := response.FindHypermediaLink(rel)
+				So(err, ShouldBeNil)
+				So(href, ShouldEqual, expected)
+			}
+		})
+
+		Convey("should find Link headers", func() {
+			response := api.NewResponse(http.StatusOK, []byte("<html></html>"), nil, http.Header{
+				"Link": {`<https://api.test/items?page=3>; rel="next last"`},
+			})
+
+			href, err := response.FindHypermediaLink("last")
+			So(err, ShouldBeNil)
+			So(href, ShouldEqual, "https://api.test/items?page=3")
+
+			_, err = response.FindHypermediaLink("prev")
+			So(err, ShouldBeLikeError, api.ErrNoLink)
+
+			_, err = json(`{"_links": {}}`, http.Header{}).FindHypermediaLink("orders.0.self")
+			So(err, ShouldBeLikeError, api.ErrNoLink)
+		})
+	})
+}
+
+func TestUnit_Client_ResolveLink(t *testing.T) {
+	Convey("Given a redirected hypermedia endpoint", t, func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/old" {
+				http.Redirect(w, r, "/api/orders", http.StatusFound)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/hal+json")
+			_, _ = w.Write([]byte(`{"_links": {"next": {"href": "orders?page=2"}}}`))
+		}))
+		defer server.Close()
+
+		cli, err := api.NewClient(&http.Client{})
+		So(err, ShouldBeNil)
+
+		_, err = cli.ResolveLink("next")
+		So(err, ShouldBeLikeError, api.ErrNoLink)
+
+		So(cli.EmitRequest(api.PrepareRequest(false).SetMethod(http.MethodGet).SetEndpoint(server.URL+"/old")), ShouldBeNil)
+
+		Convey("should resolve links against final URL", func() {
+			target, err := cli.ResolveLink("next")
+			So(err, ShouldBeNil)
+			So(target, ShouldEqual, server.URL+"/api/orders?page=2")
+		})
+	})
+}
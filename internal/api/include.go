@@ -0,0 +1,210 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/cucumber/godog"
+
+	match "github.com/elmagician/kactus/internal/matchers"
+)
+
+const (
+	inlineMatcherStart = "<<"
+	inlineMatcherEnd   = ">>"
+)
+
+// ErrNotIncluded is thrown when expected JSON is not a subset of actual JSON.
+var ErrNotIncluded = errors.New("expected JSON is not included in actual JSON")
+
+// matchers names containing spaces. They have to be checked before splitting
+// inline matcher on first space.
+var multiWordMatchers = []string{"not zero", "length equals", "size is"}
+
+// JSONIncludes asserts expected JSON is a recursive subset of response body.
+//
+// Objects only need to contain expected keys. Arrays need to have the same length
+// and matching elements in the same order, unless unordered is true: each expected
+// element then has to match a distinct actual element whatever its position.
+//
+// String values can embed a matcher expression as `<<matcher value>>`:
+//
+//	{"id": "<<defined>>", "createdAt": "<<match ^\\d{4}->>", "tags": "<<length equals 2>>"}
+func (r Response) JSONIncludes(expectedBody *godog.DocString, unordered bool) error {
+	var expected interface{}
+
+	if err := r.bodyError(); err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal([]byte(expectedBody.Content), &expected); err != nil {
+		return err
+	}
+
+	actual, err := r.Decode()
+	if err != nil {
+		return err
+	}
+
+	return Includes(expected, actual, unordered)
+}
+
+// Includes asserts expected decoded JSON is a recursive subset of actual decoded JSON.
+// See Response.JSONIncludes for matching rules.
+func Includes(expected, actual interface{}, unordered bool) error {
+	return includes("", expected, actual, unordered)
+}
+
+func includes(path string, expected, actual interface{}, unordered bool) error {
+	switch expectedVal := expected.(type) {
+	case map[string]interface{}:
+		actualVal, ok := actual.(map[string]interface{})
+		if !ok {
+			return includeError(path, "expected an object, got %v", actual)
+		}
+
+		for key, expectedChild := range expectedVal {
+			actualChild, exists := actualVal[key]
+			if !exists {
+				return fmt.Errorf("%w: %v", ErrUnknownKey, joinPath(path, key))
+			}
+
+			if err := includes(joinPath(path, key), expectedChild, actualChild, unordered); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case []interface{}:
+		actualVal, ok := actual.([]interface{})
+		if !ok {
+			return includeError(path, "expected an array, got %v", actual)
+		}
+
+		if unordered {
+			return includesUnordered(path, expectedVal, actualVal)
+		}
+
+		if len(expectedVal) != len(actualVal) {
+			return includeError(path, "expected %d elements, got %d", len(expectedVal), len(actualVal))
+		}
+
+		for i := range expectedVal {
+			if err := includes(joinPath(path, fmt.Sprint(i)), expectedVal[i], actualVal[i], unordered); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case string:
+		if method, value, ok := parseInlineMatcher(expectedVal); ok {
+			if err := match.Assert(method, actual, value); err != nil {
+				return fmt.Errorf("%s: %w", displayPath(path), err)
+			}
+
+			return nil
+		}
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		return includeError(path, "expected %v, got %v", expected, actual)
+	}
+
+	return nil
+}
+
+// includesUnordered finds a distinct actual element for each expected element
+// using augmenting paths so a greedy choice cannot hide a valid assignment.
+func includesUnordered(path string, expected, actual []interface{}) error {
+	if len(expected) > len(actual) {
+		return includeError(path, "expected at least %d elements, got %d", len(expected), len(actual))
+	}
+
+	candidates := make([][]int, len(expected))
+
+	for i, expectedElement := range expected {
+		for j, actualElement := range actual {
+			if includes(path, expectedElement, actualElement, true) == nil {
+				candidates[i] = append(candidates[i], j)
+			}
+		}
+
+		if len(candidates[i]) == 0 {
+			return includeError(path, "no element matches %v", expectedElement)
+		}
+	}
+
+	owner := make(map[int]int)
+
+	var assign func(i int, seen map[int]bool) bool
+	assign = func(i int, seen map[int]bool) bool {
+		for _, j := range candidates[i] {
+			if seen[j] {
+				continue
+			}
+
+			seen[j] = true
+
+			if current, taken := owner[j]; !taken || assign(current, seen) {
+				owner[j] = i
+				return true
+			}
+		}
+
+		return false
+	}
+
+	for i := range expected {
+		if !assign(i, make(map[int]bool)) {
+			return includeError(path, "no distinct element left to match %v", expected[i])
+		}
+	}
+
+	return nil
+}
+
+// parseInlineMatcher extracts matcher method and expected value from a
+// `<<method value>>` string.
+func parseInlineMatcher(candidate string) (method, value string, ok bool) {
+	if !strings.HasPrefix(candidate, inlineMatcherStart) || !strings.HasSuffix(candidate, inlineMatcherEnd) {
+		return "", "", false
+	}
+
+	expression := strings.TrimSpace(candidate[len(inlineMatcherStart) : len(candidate)-len(inlineMatcherEnd)])
+
+	for _, name := range multiWordMatchers {
+		if strings.HasPrefix(strings.ToLower(expression), name) {
+			return name, strings.TrimSpace(expression[len(name):]), true
+		}
+	}
+
+	parts := strings.SplitN(expression, " ", 2) // nolint: gomnd
+	if len(parts) == 1 {
+		return parts[0], "", true
+	}
+
+	return parts[0], parts[1], true
+}
+
+func includeError(path, format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s: %s", ErrNotIncluded, displayPath(path), fmt.Sprintf(format, args...))
+}
+
+func joinPath(base, key string) string {
+	if base == "" {
+		return key
+	}
+
+	return base + "." + key
+}
+
+func displayPath(path string) string {
+	if path == "" {
+		return "<root>"
+	}
+
+	return path
+}
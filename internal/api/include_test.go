@@ -0,0 +1,57 @@
+package api_test
+
+import (
+	"testing"
+
+	"github.com/cucumber/godog"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/elmagician/kactus/internal/api"
+	. "github.com/elmagician/kactus/internal/test"
+)
+
+func TestUnit_Response_JSONIncludes(t *testing.T) {
+	Convey("Given a JSON response", t, func() {
+		r := api.Response{Body: []byte(`{
+			"id": 12,
+			"createdAt": "2021-03-04T10:00:00Z",
+			"owner": {"name": "fred", "surname": "weasley"},
+			"tags": ["magic", "joke", "shop"],
+			"items": [{"sku": "a", "qty": 1}, {"sku": "b", "qty": 2}]
+		}`)}
+
+		include := func(content string, unordered bool) error {
+			return r.JSONIncludes(&godog.DocString{Content: content}, unordered)
+		}
+
+		Convey("subsets should be included", func() {
+			So(include(`{"owner": {"name": "fred"}}`, false), ShouldBeNil)
+			So(include(`{"id": 12, "items": [{"sku": "a"}, {"qty": 2}]}`, false), ShouldBeNil)
+		})
+
+		Convey("inline matchers should be applied", func() {
+			So(include(`{"createdAt": "<<match ^\\d{4}->>", "id": "<<defined>>"}`, false), ShouldBeNil)
+			So(include(`{"tags": "<<length equals 3>>", "owner": {"name": "<<in fred,george>>"}}`, false), ShouldBeNil)
+			So(include(`{"createdAt": "<<match ^\\d{2}:>>"}`, false), ShouldNotBeNil)
+		})
+
+		Convey("arrays should respect order unless asked", func() {
+			So(include(`{"tags": ["shop", "magic", "joke"]}`, false), ShouldBeLikeError, api.ErrNotIncluded)
+			So(include(`{"tags": ["shop", "magic", "joke"]}`, true), ShouldBeNil)
+			So(include(`{"tags": ["shop"]}`, true), ShouldBeNil)
+			So(include(`{"items": [{"sku": "<<defined>>"}, {"sku": "a"}]}`, true), ShouldBeNil)
+			So(include(`{"tags": ["shop", "shop"]}`, true), ShouldBeLikeError, api.ErrNotIncluded)
+		})
+
+		Convey("missing or different values should fail", func() {
+			So(include(`{"owner": {"age": 12}}`, false), ShouldBeLikeError, api.ErrUnknownKey)
+			So(include(`{"id": 13}`, false), ShouldBeLikeError, api.ErrNotIncluded)
+			So(include(`{"owner": []}`, false), ShouldBeLikeError, api.ErrNotIncluded)
+		})
+
+		Convey("empty body should fail", func() {
+			r.Body = nil
+			So(include(`{}`, false), ShouldBeError, api.ErrNoBody)
+		})
+	})
+}
@@ -0,0 +1,323 @@
+package api
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rsa"
+	_ "crypto/sha256" // register hash for crypto.SHA256
+	_ "crypto/sha512" // register hash for crypto.SHA384 and crypto.SHA512
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/cucumber/godog"
+
+	"github.com/elmagician/kactus/internal/interfaces"
+)
+
+const jwtParts = 3
+
+var (
+	// ErrInvalidJWT is thrown when a token cannot be decoded as a JWT.
+	ErrInvalidJWT = errors.New("invalid JWT")
+	// ErrJWTSignature is thrown when a token signature cannot be verified.
+	ErrJWTSignature = errors.New("JWT signature verification failed")
+	// ErrJWTTime is thrown when a token is not valid at checked time.
+	ErrJWTTime = errors.New("JWT is not valid at this time")
+)
+
+// JWT is a decoded JSON Web Token.
+type JWT struct {
+	Raw       string
+	Header    map[string]interface{}
+	Claims    map[string]interface{}
+	Signature []byte
+}
+
+type (
+	jwks struct {
+		Keys []jwk `json:"keys"`
+	}
+
+	jwk struct {
+		Kty string `json:"kty"`
+		Kid string `json:"kid"`
+		Crv string `json:"crv"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+		X   string `json:"x"`
+		Y   string `json:"y"`
+		K   string `json:"k"`
+	}
+)
+
+// ParseJWT decodes a compact serialized JWT without verifying it.
+// A leading `Bearer ` is ignored.
+func ParseJWT(token string) (*JWT, error) {
+	token = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(token), "Bearer "))
+
+	parts := strings.Split(token, ".")
+	if len(parts) != jwtParts {
+		return nil, fmt.Errorf("%w: expected %d parts, got %d", ErrInvalidJWT, jwtParts, len(parts))
+	}
+
+	jwt := &JWT{Raw: token}
+
+	if err := decodeJWTPart(parts[0], &jwt.Header); err != nil {
+		return nil, fmt.Errorf("%w: header: %s", ErrInvalidJWT, err.Error())
+	}
+
+	if err := decodeJWTPart(parts[1], &jwt.Claims); err != nil {
+		return nil, fmt.Errorf("%w: claims: %s", ErrInvalidJWT, err.Error())
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: signature: %s", ErrInvalidJWT, err.Error())
+	}
+
+	jwt.Signature = signature
+
+	return jwt, nil
+}
+
+// Algorithm returns token `alg` header.
+func (jwt JWT) Algorithm() string {
+	alg, _ := jwt.Header["alg"].(string) // nolint: errcheck
+	return alg
+}
+
+// Claim retrieves a claim using a `.` separated path.
+func (jwt JWT) Claim(path string) (interface{}, error) {
+	val, ok := interfaces.GetFieldFromPath(jwt.Claims, path)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKey, path)
+	}
+
+	if !val.IsValid() { // JSON null
+		return nil, nil
+	}
+
+	return val.Interface(), nil
+}
+
+// HeaderMatches asserts token header matches a `field | matcher | value` table.
+func (jwt JWT) HeaderMatches(expected *godog.Table) error {
+	return FieldsMatch(jwt.Header, expected)
+}
+
+// ClaimsMatch asserts token claims match a `field | matcher | value` table.
+func (jwt JWT) ClaimsMatch(expected *godog.Table) error {
+	return FieldsMatch(jwt.Claims, expected)
+}
+
+// CheckTime ensures token `exp` and `nbf` claims allow token usage at provided time.
+// Leeway is applied to both boundaries.
+func (jwt JWT) CheckTime(now time.Time, leeway time.Duration) error {
+	if exp, ok := jwt.numericDate("exp"); ok && !now.Add(-leeway).Before(exp) {
+		return fmt.Errorf("%w: expired at %s", ErrJWTTime, exp.Format(time.RFC3339))
+	}
+
+	if nbf, ok := jwt.numericDate("nbf"); ok && now.Add(leeway).Before(nbf) {
+		return fmt.Errorf("%w: not before %s", ErrJWTTime, nbf.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// IsExpired returns true if token `exp` claim is in the past.
+func (jwt JWT) IsExpired(now time.Time) bool {
+	exp, ok := jwt.numericDate("exp")
+	return ok && !now.Before(exp)
+}
+
+// VerifyWithSecret verifies an HS256/HS384/HS512 token signature.
+func (jwt JWT) VerifyWithSecret(secret []byte) error {
+	return jwt.verify(jwk{Kty: "oct", K: base64.RawURLEncoding.EncodeToString(secret)})
+}
+
+// VerifyWithJWKS verifies token signature using a JSON Web Key Set.
+// If token has a `kid` header, only the matching key is used.
+func (jwt JWT) VerifyWithJWKS(set []byte) error {
+	var keys jwks
+
+	if err := json.Unmarshal(set, &keys); err != nil {
+		return err
+	}
+
+	kid, _ := jwt.Header["kid"].(string) // nolint: errcheck
+	lastErr := fmt.Errorf("%w: no matching key in JWKS", ErrJWTSignature)
+
+	for _, key := range keys.Keys {
+		if kid != "" && key.Kid != kid {
+			continue
+		}
+
+		if lastErr = jwt.verify(key); lastErr == nil {
+			return nil
+		}
+	}
+
+	return lastErr
+}
+
+func (jwt JWT) verify(key jwk) error { // nolint: gocyclo
+	alg := jwt.Algorithm()
+	signed := jwt.Raw[:strings.LastIndex(jwt.Raw, ".")]
+
+	hashFn, err := jwtHash(alg)
+	if err != nil {
+		return err
+	}
+
+	digest := hashFn.New()
+	digest.Write([]byte(signed)) // nolint: errcheck
+	sum := digest.Sum(nil)
+
+	switch {
+	case strings.HasPrefix(alg, "HS") && key.Kty == "oct":
+		secret, err := base64.RawURLEncoding.DecodeString(key.K)
+		if err != nil {
+			return err
+		}
+
+		mac := hmac.New(hashFn.New, secret)
+		mac.Write([]byte(signed)) // nolint: errcheck
+
+		if !hmac.Equal(mac.Sum(nil), jwt.Signature) {
+			return fmt.Errorf("%w: HMAC does not match", ErrJWTSignature)
+		}
+
+		return nil
+	case (strings.HasPrefix(alg, "RS") || strings.HasPrefix(alg, "PS")) && key.Kty == "RSA":
+		pub, err := key.rsaKey()
+		if err != nil {
+			return err
+		}
+
+		if strings.HasPrefix(alg, "PS") {
+			err = rsa.VerifyPSS(pub, hashFn, sum, jwt.Signature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+		} else {
+			err = rsa.VerifyPKCS1v15(pub, hashFn, sum, jwt.Signature)
+		}
+
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrJWTSignature, err.Error())
+		}
+
+		return nil
+	case strings.HasPrefix(alg, "ES") && key.Kty == "EC":
+		pub, err := key.ecdsaKey()
+		if err != nil {
+			return err
+		}
+
+		size := len(jwt.Signature) / 2 // nolint: gomnd
+		r := new(big.Int).SetBytes(jwt.Signature[:size])
+		s := new(big.Int).SetBytes(jwt.Signature[size:])
+
+		if !ecdsa.Verify(pub, sum, r, s) {
+			return fmt.Errorf("%w: ECDSA signature does not match", ErrJWTSignature)
+		}
+
+		return nil
+	case alg == "EdDSA" && key.Kty == "OKP":
+		x, err := base64.RawURLEncoding.DecodeString(key.X)
+		if err != nil {
+			return err
+		}
+
+		if key.Crv != "Ed25519" || len(x) != ed25519.PublicKeySize {
+			return fmt.Errorf("%w: unsupported OKP key", ErrJWTSignature)
+		}
+
+		if !ed25519.Verify(x, []byte(signed), jwt.Signature) {
+			return fmt.Errorf("%w: EdDSA signature does not match", ErrJWTSignature)
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("%w: key type %s cannot verify %s tokens", ErrJWTSignature, key.Kty, alg)
+}
+
+func (jwt JWT) numericDate(claim string) (time.Time, bool) {
+	value, ok := jwt.Claims[claim].(float64)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	return time.Unix(int64(value), 0), true
+}
+
+func (key jwk) rsaKey() (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(key.N)
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := base64.RawURLEncoding.DecodeString(key.E)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+}
+
+func (key jwk) ecdsaKey() (*ecdsa.PublicKey, error) {
+	var curve elliptic.Curve
+
+	switch key.Crv {
+	case "P-256":
+		curve = elliptic.P256()
+	case "P-384":
+		curve = elliptic.P384()
+	case "P-521":
+		curve = elliptic.P521()
+	default:
+		return nil, fmt.Errorf("%w: unsupported curve %s", ErrJWTSignature, key.Crv)
+	}
+
+	x, err := base64.RawURLEncoding.DecodeString(key.X)
+	if err != nil {
+		return nil, err
+	}
+
+	y, err := base64.RawURLEncoding.DecodeString(key.Y)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+}
+
+func jwtHash(alg string) (crypto.Hash, error) {
+	switch {
+	case alg == "EdDSA":
+		return crypto.SHA512, nil
+	case strings.HasSuffix(alg, "256"):
+		return crypto.SHA256, nil
+	case strings.HasSuffix(alg, "384"):
+		return crypto.SHA384, nil
+	case strings.HasSuffix(alg, "512"):
+		return crypto.SHA512, nil
+	}
+
+	return 0, fmt.Errorf("%w: unsupported algorithm %s", ErrJWTSignature, alg)
+}
+
+func decodeJWTPart(part string, into interface{}) error {
+	raw, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(part, "="))
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(raw, into)
+}
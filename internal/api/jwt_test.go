@@ -0,0 +1,128 @@
+package api_test
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/elmagician/kactus/internal/api"
+	. "github.com/elmagician/kactus/internal/test"
+)
+
+func encodeJWTPart(v interface{}) string {
+	raw, _ := json.Marshal(v) // nolint: errcheck
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func TestUnit_ParseJWT(t *testing.T) {
+	Convey("When I parse a JWT", t, func() {
+		claims := map[string]interface{}{"sub": "user-1", "roles": []string{"admin"}, "exp": 2000000000, "nonce": nil}
+		token := encodeJWTPart(map[string]string{"alg": "HS256", "typ": "JWT"}) + "." + encodeJWTPart(claims) + ".c2ln"
+
+		Convey("should decode header and claims", func() {
+			jwt, err := api.ParseJWT("Bearer " + token)
+
+			So(err, ShouldBeNil)
+			So(jwt.Algorithm(), ShouldEqual, "HS256")
+			So(jwt.Claims["sub"], ShouldEqual, "user-1")
+
+			role, err := jwt.Claim("roles.0")
+			So(err, ShouldBeNil)
+			So(role, ShouldEqual, "admin")
+
+			nonce, err := jwt.Claim("nonce")
+			So(err, ShouldBeNil)
+			So(nonce, ShouldBeNil)
+		})
+
+		Convey("should match claims table", func() {
+			jwt, err := api.ParseJWT(token)
+			So(err, ShouldBeNil)
+
+			So(jwt.ClaimsMatch(NewTable(
+				[]string{"field", "matcher", "value"},
+				[]string{"sub", "=", "user-1"},
+				[]string{"roles", "l=", "1"},
+			)), ShouldBeNil)
+
+			So(jwt.ClaimsMatch(NewTable(
+				[]string{"field", "matcher", "value"},
+				[]string{"sub", "=", "user-2"},
+			)), ShouldNotBeNil)
+		})
+
+		Convey("should fail on malformed token", func() {
+			_, err := api.ParseJWT("not.a")
+
+			So(err, ShouldBeLikeError, api.ErrInvalidJWT)
+		})
+	})
+}
+
+func TestUnit_JWT_CheckTime(t *testing.T) {
+	Convey("When I check JWT validity time", t, func() {
+		now := time.Unix(1000, 0)
+		jwt := api.JWT{Claims: map[string]interface{}{"nbf": float64(900), "exp": float64(1100)}}
+
+		So(jwt.CheckTime(now, 0), ShouldBeNil)
+		So(jwt.IsExpired(now), ShouldBeFalse)
+
+		So(jwt.CheckTime(time.Unix(1200, 0), 0), ShouldBeLikeError, api.ErrJWTTime)
+		So(jwt.CheckTime(time.Unix(800, 0), 0), ShouldBeLikeError, api.ErrJWTTime)
+		So(jwt.CheckTime(time.Unix(850, 0), time.Minute), ShouldBeNil)
+		So(jwt.IsExpired(time.Unix(1200, 0)), ShouldBeTrue)
+	})
+}
+
+func TestUnit_JWT_Verify(t *testing.T) {
+	Convey("When I verify a JWT signature", t, func() {
+		payload := encodeJWTPart(map[string]string{"sub": "user-1"})
+
+		Convey("should verify HMAC signature using shared secret", func() {
+			signed := encodeJWTPart(map[string]string{"alg": "HS256"}) + "." + payload
+			mac := hmac.New(sha256.New, []byte("secret"))
+			mac.Write([]byte(signed)) // nolint: errcheck
+
+			jwt, err := api.ParseJWT(signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)))
+			So(err, ShouldBeNil)
+
+			So(jwt.VerifyWithSecret([]byte("secret")), ShouldBeNil)
+			So(jwt.VerifyWithSecret([]byte("other")), ShouldBeLikeError, api.ErrJWTSignature)
+		})
+
+		Convey("should verify RSA signature using JWKS", func() {
+			key, err := rsa.GenerateKey(rand.Reader, 2048)
+			So(err, ShouldBeNil)
+
+			signed := encodeJWTPart(map[string]string{"alg": "RS256", "kid": "k1"}) + "." + payload
+			sum := sha256.Sum256([]byte(signed))
+			signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, sum[:])
+			So(err, ShouldBeNil)
+
+			jwt, err := api.ParseJWT(signed + "." + base64.RawURLEncoding.EncodeToString(signature))
+			So(err, ShouldBeNil)
+
+			jwks := fmt.Sprintf(
+				`{"keys":[{"kty":"RSA","kid":"k1","n":"%s","e":"%s"}]}`,
+				base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			)
+
+			So(jwt.VerifyWithJWKS([]byte(jwks)), ShouldBeNil)
+
+			Convey("and fail if no key matches kid", func() {
+				So(jwt.VerifyWithJWKS([]byte(`{"keys":[{"kty":"RSA","kid":"k2"}]}`)), ShouldBeLikeError, api.ErrJWTSignature)
+			})
+		})
+	})
+}
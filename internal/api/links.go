@@ -0,0 +1,92 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Link is a web link parsed from an RFC 8288 Link header.
+type Link struct {
+	URL    string
+	Rel    string
+	Params map[string]string
+}
+
+// ParseLinkHeader parses all Link header values. A link with multiple
+// relation types is returned once per relation type.
+func ParseLinkHeader(headers http.Header) []Link {
+	var links []Link
+
+	for _, value := range headers.Values("Link") {
+		for _, raw := range splitLinks(value) {
+			raw = strings.TrimSpace(raw)
+			if !strings.HasPrefix(raw, "<") || !strings.Contains(raw, ">") {
+				continue
+			}
+
+			end := strings.Index(raw, ">")
+			target := raw[1:end]
+			params := make(map[string]string)
+
+			for _, param := range strings.Split(raw[end+1:], ";") {
+				param = strings.TrimSpace(param)
+				if param == "" {
+					continue
+				}
+
+				kv := strings.SplitN(param, "=", 2) // nolint: gomnd
+				key := strings.ToLower(strings.TrimSpace(kv[0]))
+
+				if len(kv) == 1 {
+					params[key] = ""
+					continue
+				}
+
+				params[key] = strings.Trim(strings.TrimSpace(kv[1]), `"`)
+			}
+
+			for _, rel := range strings.Fields(params["rel"]) {
+				links = append(links, Link{URL: target, Rel: strings.ToLower(rel), Params: params})
+			}
+		}
+	}
+
+	return links
+}
+
+// FindLink returns target of the first Link header matching relation type.
+func FindLink(headers http.Header, rel string) (string, bool) {
+	for _, link := range ParseLinkHeader(headers) {
+		if link.Rel == strings.ToLower(rel) {
+			return link.URL, true
+		}
+	}
+
+	return "", false
+}
+
+// splitLinks splits a Link header value on commas outside of <> and quotes.
+func splitLinks(value string) []string {
+	var (
+		parts   []string
+		inURL   bool
+		inQuote bool
+		start   int
+	)
+
+	for i, c := range value {
+		switch {
+		case c == '<' && !inQuote:
+			inURL = true
+		case c == '>' && !inQuote:
+			inURL = false
+		case c == '"' && !inURL:
+			inQuote = !inQuote
+		case c == ',' && !inURL && !inQuote:
+			parts = append(parts, value[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(parts, value[start:])
+}
@@ -0,0 +1,267 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// PaginateByLink follows `Link: <...>; rel="next"` response headers.
+	PaginateByLink PaginationMode = "link"
+	// PaginateByCursor follows a cursor read from response JSON body.
+	PaginateByCursor PaginationMode = "cursor"
+	// PaginateByPage increments a page query parameter.
+	PaginateByPage PaginationMode = "page"
+	// PaginateByOffset increments an offset query parameter by page size.
+	PaginateByOffset PaginationMode = "offset"
+
+	defaultMaxPages = 100
+)
+
+var (
+	// ErrTooManyPages is thrown when pagination still has pages after MaxPages requests.
+	ErrTooManyPages = errors.New("pagination exceeded max pages")
+	// ErrPaginationStatus is thrown when a page is not answered with a 2XX status.
+	ErrPaginationStatus = errors.New("unexpected status while paginating")
+)
+
+type (
+	// PaginationMode defines how next page is requested.
+	PaginationMode string
+
+	// Pagination describes how to traverse a paginated endpoint.
+	Pagination struct {
+		Mode PaginationMode
+
+		// ItemsPath locates items in each page JSON body. Empty means body root.
+		ItemsPath string
+
+		// CursorPath locates next cursor in page JSON body. Cursor mode only.
+		// If cursor is an URL, it is requested as is.
+		CursorPath string
+		// CursorParam is the query parameter receiving the cursor.
+		CursorParam string
+
+		// PageParam is the page query parameter. Page mode only.
+		PageParam string
+		// StartPage is the first page number.
+		StartPage int
+
+		// OffsetParam and LimitParam are offset and page size query parameters.
+		// Offset mode only.
+		OffsetParam string
+		LimitParam  string
+		// Limit is the page size. It is sent using LimitParam if greater than 0.
+		Limit int
+
+		// MaxPages stops pagination with an error if more pages remain.
+		MaxPages int
+	}
+)
+
+// NewPagination initializes a Pagination from options.
+// Known options are mode, items, cursor, cursor_param, page_param, start_page,
+// offset_param, limit_param, limit and max_pages.
+func NewPagination(options map[string]string) (Pagination, error) {
+	var err error
+
+	pagination := Pagination{
+		Mode:        PaginationMode(withDefault(options["mode"], string(PaginateByLink))),
+		ItemsPath:   options["items"],
+		CursorPath:  withDefault(options["cursor"], "next"),
+		CursorParam: withDefault(options["cursor_param"], "cursor"),
+		PageParam:   withDefault(options["page_param"], "page"),
+		OffsetParam: withDefault(options["offset_param"], "offset"),
+		LimitParam:  withDefault(options["limit_param"], "limit"),
+		StartPage:   1,
+		MaxPages:    defaultMaxPages,
+	}
+
+	for name, into := range map[string]*int{
+		"start_page": &pagination.StartPage,
+		"limit":      &pagination.Limit,
+		"max_pages":  &pagination.MaxPages,
+	} {
+		if value, ok := options[name]; ok && value != "" {
+			if *into, err = strconv.Atoi(value); err != nil {
+				return pagination, fmt.Errorf("%w: %s should be an integer", ErrInvalidOption, name)
+			}
+		}
+	}
+
+	switch pagination.Mode {
+	case PaginateByLink, PaginateByCursor, PaginateByPage, PaginateByOffset:
+	default:
+		return pagination, fmt.Errorf("%w: unknown pagination mode %s", ErrInvalidOption, pagination.Mode)
+	}
+
+	if pagination.MaxPages < 1 {
+		return pagination, fmt.Errorf("%w: max_pages should be positive", ErrInvalidOption)
+	}
+
+	return pagination, nil
+}
+
+// Paginate emits request then follows pages until the last one, aggregating items
+// of every page. Client Response is left to the last page response.
+func (cli *Client) Paginate(req RequestPreparation, pagination Pagination) ([]interface{}, error) {
+	var items []interface{}
+
+	req = req.SetMethod("GET")
+	req.Arguments = copyArguments(req.Arguments)
+	page, offset := pagination.StartPage, 0
+
+	switch pagination.Mode { // nolint: exhaustive
+	case PaginateByPage:
+		req.Arguments[pagination.PageParam] = strconv.Itoa(page)
+	case PaginateByOffset:
+		req.Arguments[pagination.OffsetParam] = "0"
+
+		if pagination.Limit > 0 {
+			req.Arguments[pagination.LimitParam] = strconv.Itoa(pagination.Limit)
+		}
+	}
+
+	for count := 1; ; count++ {
+		if err := cli.EmitRequest(req); err != nil {
+			return nil, err
+		}
+
+		if cli.Response.Status < 200 || cli.Response.Status >= 300 {
+			return nil, fmt.Errorf("%w: page %d got %d", ErrPaginationStatus, count, cli.Response.Status)
+		}
+
+		pageItems, err := cli.Response.RetrieveJSONArray(pagination.ItemsPath)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, pageItems...)
+
+		log.Debug("retrieved page", zap.Int("page", count), zap.Int("items", len(pageItems)))
+
+		next, hasNext, err := cli.nextPage(req, pagination, len(pageItems), &page, &offset)
+		if err != nil || !hasNext {
+			return items, err
+		}
+
+		if count >= pagination.MaxPages {
+			return nil, fmt.Errorf("%w: stopped after %d pages", ErrTooManyPages, count)
+		}
+
+		req = next
+	}
+}
+
+func (cli *Client) nextPage(
+	req RequestPreparation, pagination Pagination, pageSize int, page, offset *int,
+) (RequestPreparation, bool, error) {
+	switch pagination.Mode {
+	case PaginateByLink:
+		target, ok := FindLink(cli.Response.Headers, "next")
+		if !ok {
+			return req, false, nil
+		}
+
+		return cli.followURL(req, target)
+	case PaginateByCursor:
+		cursor, err := cli.Response.RetrieveJSON(pagination.CursorPath)
+		if err != nil && !errors.Is(err, ErrUnknownKey) {
+			return req, false, err
+		}
+
+		if err != nil || cursor == nil || cursor == "" {
+			return req, false, nil
+		}
+
+		value := fmt.Sprintf("%v", cursor)
+		if parsed, parseErr := url.Parse(value); parseErr == nil && parsed.IsAbs() {
+			return cli.followURL(req, value)
+		}
+
+		req.Arguments = copyArguments(req.Arguments)
+		req.Arguments[pagination.CursorParam] = value
+
+		return req, true, nil
+	case PaginateByPage:
+		if pageSize == 0 {
+			return req, false, nil
+		}
+
+		*page++
+		req.Arguments = copyArguments(req.Arguments)
+		req.Arguments[pagination.PageParam] = strconv.Itoa(*page)
+
+		return req, true, nil
+	case PaginateByOffset:
+		if pageSize == 0 || (pagination.Limit > 0 && pageSize < pagination.Limit) {
+			return req, false, nil
+		}
+
+		*offset += pageSize
+		req.Arguments = copyArguments(req.Arguments)
+		req.Arguments[pagination.OffsetParam] = strconv.Itoa(*offset)
+
+		return req, true, nil
+	}
+
+	return req, false, fmt.Errorf("%w: unknown pagination mode %s", ErrInvalidOption, pagination.Mode)
+}
+
+// followURL prepares a request on target resolved against last emitted request URL.
+// Target query replaces prepared arguments.
+func (cli *Client) followURL(req RequestPreparation, target string) (RequestPreparation, bool, error) {
+	resolved, err := cli.ResolveURL(target)
+	if err != nil {
+		return req, false, err
+	}
+
+	req.Endpoint = resolved
+	req.Arguments = make(map[string]string)
+
+	return req, true, nil
+}
+
+// ResolveURL resolves a possibly relative URL against URL which answered
+// last response, after redirections, or last emitted request URL.
+// URLs targeting an `unix://` endpoint host keep targeting its socket.
+func (cli *Client) ResolveURL(target string) (string, error) {
+	parsed, err := url.Parse(target)
+	if err != nil {
+		return "", err
+	}
+
+	if cli.request == nil {
+		return parsed.String(), nil
+	}
+
+	base := cli.request.URL
+
+	if cli.Response != nil && cli.Response.URL != "" {
+		if base, err = url.Parse(cli.Response.URL); err != nil {
+			return "", err
+		}
+	}
+
+	resolved := base.ResolveReference(parsed)
+
+	if endpoint, ok := cli.unixEndpoint(resolved); ok {
+		return endpoint, nil
+	}
+
+	return resolved.String(), nil
+}
+
+func copyArguments(arguments map[string]string) map[string]string {
+	copied := make(map[string]string, len(arguments))
+
+	for key, value := range arguments {
+		copied[key] = value
+	}
+
+	return copied
+}
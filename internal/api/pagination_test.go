@@ -0,0 +1,105 @@
+package api_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/elmagician/kactus/internal/api"
+	. "github.com/elmagician/kactus/internal/test"
+)
+
+func TestUnit_ParseLinkHeader(t *testing.T) {
+	Convey("When I parse Link headers", t, func() {
+		headers := http.Header{}
+		headers.Add("Link", `</items?page=2>; rel="next", </items?page=1>; rel="first prev"`)
+		headers.Add("Link", `<https://example.com/a,b>; rel=last; title="a, b"`)
+
+		links := api.ParseLinkHeader(headers)
+
+		So(links, ShouldHaveLength, 4)
+		So(links[0].URL, ShouldEqual, "/items?page=2")
+		So(links[3].URL, ShouldEqual, "https://example.com/a,b")
+		So(links[3].Params["title"], ShouldEqual, "a, b")
+
+		prev, ok := api.FindLink(headers, "prev")
+		So(ok, ShouldBeTrue)
+		So(prev, ShouldEqual, "/items?page=1")
+
+		_, ok = api.FindLink(headers, "self")
+		So(ok, ShouldBeFalse)
+	})
+}
+
+func TestUnit_Client_Paginate(t *testing.T) {
+	Convey("Given a paginated endpoint", t, func() {
+		pages := [][]int{{1, 2}, {3, 4}, {5}}
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			page := 1
+
+			switch {
+			case r.URL.Query().Get("page") != "":
+				page, _ = strconv.Atoi(r.URL.Query().Get("page")) // nolint: errcheck
+			case r.URL.Query().Get("cursor") != "":
+				page, _ = strconv.Atoi(r.URL.Query().Get("cursor")) // nolint: errcheck
+			case r.URL.Query().Get("offset") != "":
+				offset, _ := strconv.Atoi(r.URL.Query().Get("offset")) // nolint: errcheck
+				page = offset/2 + 1
+			}
+
+			items := []byte("[]")
+			if page <= len(pages) {
+				items, _ = json.Marshal(pages[page-1]) // nolint: errcheck
+			}
+
+			next := "null"
+			if page < len(pages) {
+				next = strconv.Itoa(page + 1)
+				w.Header().Set("Link", fmt.Sprintf(`</items?page=%d>; rel="next"`, page+1))
+			}
+
+			fmt.Fprintf(w, `{"data": %s, "next": %s}`, items, next)
+		}))
+		defer server.Close()
+
+		cli, err := api.NewClient(&http.Client{})
+		So(err, ShouldBeNil)
+
+		req := api.PrepareRequest(false).SetEndpoint(server.URL + "/items")
+
+		for _, mode := range []string{"link", "cursor", "page", "offset"} {
+			mode := mode
+
+			Convey("should collect every item following "+mode, func() {
+				pagination, err := api.NewPagination(map[string]string{"mode": mode, "items": "data", "limit": "2"})
+				So(err, ShouldBeNil)
+
+				items, err := cli.Paginate(req, pagination)
+
+				So(err, ShouldBeNil)
+				So(items, ShouldResemble, []interface{}{1.0, 2.0, 3.0, 4.0, 5.0})
+			})
+		}
+
+		Convey("should stop at max pages", func() {
+			pagination, err := api.NewPagination(map[string]string{"items": "data", "max_pages": "2"})
+			So(err, ShouldBeNil)
+
+			_, err = cli.Paginate(req, pagination)
+
+			So(err, ShouldBeLikeError, api.ErrTooManyPages)
+		})
+
+		Convey("should reject unknown modes", func() {
+			_, err := api.NewPagination(map[string]string{"mode": "magic"})
+
+			So(err, ShouldBeLikeError, api.ErrInvalidOption)
+		})
+	})
+}
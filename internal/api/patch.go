@@ -0,0 +1,379 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidPatch is thrown when a JSON Patch cannot be applied.
+	ErrInvalidPatch = errors.New("invalid json patch")
+	// ErrPatchTest is thrown when a JSON Patch test operation fails.
+	ErrPatchTest = errors.New("json patch test failed")
+)
+
+type patchOperation struct {
+	Op    string           `json:"op"`
+	Path  string           `json:"path"`
+	From  string           `json:"from"`
+	Value *json.RawMessage `json:"value"`
+}
+
+// UnmarshalJSON keeps a null value as json.Unmarshal would drop it
+// while null is a valid value for add, replace and test operations.
+func (o *patchOperation) UnmarshalJSON(data []byte) error {
+	type operation patchOperation
+
+	var fields map[string]json.RawMessage
+
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(data, (*operation)(o)); err != nil {
+		return err
+	}
+
+	if value, ok := fields["value"]; ok {
+		o.Value = &value
+	}
+
+	return nil
+}
+
+// LoadJSONFile decodes a JSON file. Numbers are kept as json.Number
+// so that they are encoded back without precision loss.
+func LoadJSONFile(path string) (interface{}, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeJSONNumbers(raw)
+}
+
+// MergePatch applies a JSON Merge Patch (RFC 7386) document to document.
+func MergePatch(document interface{}, patch string) (interface{}, error) {
+	decoded, err := decodeJSONNumbers([]byte(patch))
+	if err != nil {
+		return nil, err
+	}
+
+	return mergePatch(document, decoded), nil
+}
+
+func mergePatch(target, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = make(map[string]interface{})
+	}
+
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+			continue
+		}
+
+		targetObject[key] = mergePatch(targetObject[key], value)
+	}
+
+	return targetObject
+}
+
+// JSONPatch applies a JSON Patch (RFC 6902) operation list to document.
+func JSONPatch(document interface{}, patch string) (interface{}, error) {
+	var operations []patchOperation
+
+	if err := json.Unmarshal([]byte(patch), &operations); err != nil {
+		return nil, err
+	}
+
+	for _, operation := range operations {
+		var err error
+
+		if document, err = applyOperation(document, operation); err != nil {
+			return nil, fmt.Errorf("%s %s: %w", operation.Op, operation.Path, err)
+		}
+	}
+
+	return document, nil
+}
+
+func applyOperation(document interface{}, operation patchOperation) (interface{}, error) { // nolint: gocyclo
+	var (
+		value interface{}
+		err   error
+	)
+
+	switch operation.Op {
+	case "add", "replace", "test":
+		if operation.Value == nil {
+			return nil, fmt.Errorf("%w: missing value", ErrInvalidPatch)
+		}
+
+		if value, err = decodeJSONNumbers(*operation.Value); err != nil {
+			return nil, err
+		}
+	case "move", "copy":
+		if value, err = getPointer(document, operation.From); err != nil {
+			return nil, err
+		}
+	}
+
+	switch operation.Op {
+	case "add":
+		return updatePointer(document, operation.Path, value, true)
+	case "replace":
+		if _, err = getPointer(document, operation.Path); err != nil {
+			return nil, err
+		}
+
+		return updatePointer(document, operation.Path, value, false)
+	case "remove":
+		return removePointer(document, operation.Path)
+	case "move":
+		if document, err = removePointer(document, operation.From); err != nil {
+			return nil, err
+		}
+
+		return updatePointer(document, operation.Path, value, true)
+	case "copy":
+		return updatePointer(document, operation.Path, deepCopy(value), true)
+	case "test":
+		actual, err := getPointer(document, operation.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		if !reflect.DeepEqual(normalizeNumbers(actual), normalizeNumbers(value)) {
+			return nil, fmt.Errorf("%w: expected %v, got %v", ErrPatchTest, value, actual)
+		}
+
+		return document, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown operation %q", ErrInvalidPatch, operation.Op)
+	}
+}
+
+// parsePointer splits a JSON pointer (RFC 6901) in unescaped tokens.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("%w: pointer %q should start with /", ErrInvalidPatch, pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+	}
+
+	return tokens, nil
+}
+
+func getPointer(document interface{}, pointer string) (interface{}, error) {
+	tokens, err := parsePointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+
+	current := document
+
+	for _, token := range tokens {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			value, ok := node[token]
+			if !ok {
+				return nil, fmt.Errorf("%w: %s", ErrUnknownKey, pointer)
+			}
+
+			current = value
+		case []interface{}:
+			index, err := arrayIndex(token, len(node)-1)
+			if err != nil {
+				return nil, err
+			}
+
+			current = node[index]
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrUnknownKey, pointer)
+		}
+	}
+
+	return current, nil
+}
+
+// updatePointer sets value at pointer. When insert is true, values
+// are inserted in arrays instead of replacing existing element.
+func updatePointer(document interface{}, pointer string, value interface{}, insert bool) (interface{}, error) {
+	tokens, err := parsePointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tokens) == 0 {
+		return value, nil
+	}
+
+	parent, err := getPointer(document, parentPointer(pointer))
+	if err != nil {
+		return nil, err
+	}
+
+	last := tokens[len(tokens)-1]
+
+	switch node := parent.(type) {
+	case map[string]interface{}:
+		node[last] = value
+		return document, nil
+	case []interface{}:
+		if !insert {
+			index, err := arrayIndex(last, len(node)-1)
+			if err != nil {
+				return nil, err
+			}
+
+			node[index] = value
+
+			return document, nil
+		}
+
+		index := len(node)
+		if last != "-" {
+			if index, err = arrayIndex(last, len(node)); err != nil {
+				return nil, err
+			}
+		}
+
+		node = append(node[:index], append([]interface{}{value}, node[index:]...)...)
+
+		return updatePointer(document, parentPointer(pointer), node, false)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKey, pointer)
+	}
+}
+
+func removePointer(document interface{}, pointer string) (interface{}, error) {
+	tokens, err := parsePointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+
+	parent, err := getPointer(document, parentPointer(pointer))
+	if err != nil {
+		return nil, err
+	}
+
+	last := tokens[len(tokens)-1]
+
+	switch node := parent.(type) {
+	case map[string]interface{}:
+		if _, ok := node[last]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownKey, pointer)
+		}
+
+		delete(node, last)
+
+		return document, nil
+	case []interface{}:
+		index, err := arrayIndex(last, len(node)-1)
+		if err != nil {
+			return nil, err
+		}
+
+		node = append(node[:index:index], node[index+1:]...)
+
+		return updatePointer(document, parentPointer(pointer), node, false)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKey, pointer)
+	}
+}
+
+func parentPointer(pointer string) string {
+	return pointer[:strings.LastIndex(pointer, "/")]
+}
+
+func arrayIndex(token string, maximum int) (int, error) {
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 || index > maximum || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("%w: invalid array index %q", ErrInvalidPatch, token)
+	}
+
+	return index, nil
+}
+
+func decodeJSONNumbers(raw []byte) (interface{}, error) {
+	var document interface{}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&document); err != nil {
+		return nil, err
+	}
+
+	return document, nil
+}
+
+// normalizeNumbers converts json.Number to float64 for comparison.
+func normalizeNumbers(value interface{}) interface{} {
+	switch node := value.(type) {
+	case json.Number:
+		f, _ := node.Float64() // nolint: errcheck
+		return f
+	case map[string]interface{}:
+		normalized := make(map[string]interface{}, len(node))
+		for key, child := range node {
+			normalized[key] = normalizeNumbers(child)
+		}
+
+		return normalized
+	case []interface{}:
+		normalized := make([]interface{}, len(node))
+		for i, child := range node {
+			normalized[i] = normalizeNumbers(child)
+		}
+
+		return normalized
+	default:
+		return value
+	}
+}
+
+func deepCopy(value interface{}) interface{} {
+	switch node := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(node))
+		for key, child := range node {
+			copied[key] = deepCopy(child)
+		}
+
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(node))
+		for i, child := range node {
+			copied[i] = deepCopy(child)
+		}
+
+		return copied
+	default:
+		return value
+	}
+}
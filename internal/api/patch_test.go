@@ -0,0 +1,128 @@
+package api_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/elmagician/kactus/internal/api"
+	. "github.com/elmagician/kactus/internal/test"
+)
+
+const orderTemplate = `{
+	"id": 12345678901234567890,
+	"customer": {"name": "kactus", "email": "kactus@example.com"},
+	"lines": [{"sku": "A", "qty": 1}, {"sku": "B", "qty": 2}]
+}`
+
+func encode(document interface{}) string {
+	raw, err := json.Marshal(document)
+	So(err, ShouldBeNil)
+
+	return string(raw)
+}
+
+func TestUnit_MergePatch(t *testing.T) {
+	Convey("When I apply a JSON Merge Patch", t, func() {
+		document := map[string]interface{}{
+			"a": "b", "c": map[string]interface{}{"d": "e", "f": "g"},
+		}
+
+		patched, err := api.MergePatch(document, `{"a": "z", "c": {"f": null}, "h": [1]}`)
+
+		So(err, ShouldBeNil)
+		So(encode(patched), ShouldEqualJSON, `{"a": "z", "c": {"d": "e"}, "h": [1]}`)
+	})
+}
+
+func TestUnit_JSONPatch(t *testing.T) {
+	Convey("Given a JSON document", t, func() {
+		var document interface{}
+		So(json.Unmarshal([]byte(`{"foo": ["bar", "baz"], "a/b": 1, "obj": {"x": 1}}`), &document), ShouldBeNil)
+
+		Convey("should apply operations in order", func() {
+			patched, err := api.JSONPatch(document, `[
+				{"op": "test", "path": "/a~1b", "value": 1},
+				{"op": "add", "path": "/foo/1", "value": "qux"},
+				{"op": "add", "path": "/foo/-", "value": "end"},
+				{"op": "remove", "path": "/foo/0"},
+				{"op": "replace", "path": "/obj/x", "value": 2},
+				{"op": "copy", "from": "/obj", "path": "/copy"},
+				{"op": "move", "from": "/a~1b", "path": "/moved"}
+			]`)
+
+			So(err, ShouldBeNil)
+			So(encode(patched), ShouldEqualJSON, `{
+				"foo": ["qux", "baz", "end"], "obj": {"x": 2}, "copy": {"x": 2}, "moved": 1
+			}`)
+		})
+
+		Convey("should set null values", func() {
+			patched, err := api.JSONPatch(document, `[
+				{"op": "replace", "path": "/obj/x", "value": null},
+				{"op": "test", "path": "/obj/x", "value": null},
+				{"op": "add", "path": "/none", "value": null}
+			]`)
+
+			So(err, ShouldBeNil)
+			So(encode(patched), ShouldEqualJSON, `{"foo": ["bar", "baz"], "a/b": 1, "obj": {"x": null}, "none": null}`)
+		})
+
+		Convey("should fail on missing value", func() {
+			_, err := api.JSONPatch(document, `[{"op": "add", "path": "/none"}]`)
+
+			So(err, ShouldBeLikeError, api.ErrInvalidPatch)
+		})
+
+		Convey("should fail on failed test operation", func() {
+			_, err := api.JSONPatch(document, `[{"op": "test", "path": "/a~1b", "value": 2}]`)
+
+			So(err, ShouldBeLikeError, api.ErrPatchTest)
+		})
+
+		Convey("should fail replacing unknown paths", func() {
+			_, err := api.JSONPatch(document, `[{"op": "replace", "path": "/unknown", "value": 2}]`)
+
+			So(err, ShouldBeLikeError, api.ErrUnknownKey)
+		})
+
+		Convey("should fail on invalid operations", func() {
+			_, err := api.JSONPatch(document, `[{"op": "upsert", "path": "/foo"}]`)
+
+			So(err, ShouldBeLikeError, api.ErrInvalidPatch)
+		})
+	})
+}
+
+func TestUnit_RequestPreparation_SetJSONBodyFromFile(t *testing.T) {
+	Convey("Given a JSON body template", t, func() {
+		path := filepath.Join(t.TempDir(), "order.json")
+		So(ioutil.WriteFile(path, []byte(orderTemplate), 0o600), ShouldBeNil)
+
+		Convey("should apply table overrides and keep number precision", func() {
+			req, err := api.PrepareRequest(false).SetJSONBodyFromFile(path, NewTable(
+				[]string{"field", "value"},
+				[]string{"customer.name", "cactus"},
+				[]string{"lines.1.qty", "5((int))"},
+				[]string{"lines.2.sku", "C"},
+			))
+
+			So(err, ShouldBeNil)
+			So(*req.JSONBody, ShouldContainSubstring, "12345678901234567890")
+			So(*req.JSONBody, ShouldEqualJSON, `{
+				"id": 12345678901234567890,
+				"customer": {"name": "cactus", "email": "kactus@example.com"},
+				"lines": [{"sku": "A", "qty": 1}, {"sku": "B", "qty": 5}, {"sku": "C"}]
+			}`)
+		})
+
+		Convey("should fail on missing file", func() {
+			_, err := api.PrepareRequest(false).SetJSONBodyFromFile(path+".missing", nil)
+
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
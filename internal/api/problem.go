@@ -0,0 +1,155 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cucumber/godog"
+
+	"github.com/elmagician/kactus/internal/interfaces"
+	match "github.com/elmagician/kactus/internal/matchers"
+)
+
+// ProblemContentType is the media type of RFC 9457 problem details.
+const ProblemContentType = "application/problem+json"
+
+// problemErrors is the member listing field level errors.
+const problemErrors = "errors"
+
+// ErrProblem is thrown when response is not the expected problem details.
+var ErrProblem = errors.New("response is not the expected problem")
+
+// Problem decodes response as RFC 9457 (RFC 7807) problem details. Media type
+// must be application/problem+json, type and title must be strings, status
+// must equal response status and optional detail and instance must be strings.
+// Every violation is reported.
+func (r Response) Problem() (map[string]interface{}, error) {
+	if err := r.ContentTypeMatches(ProblemContentType); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrProblem, err)
+	}
+
+	decoded, err := r.Decode()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrProblem, err)
+	}
+
+	problem, ok := decoded.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: body is not a json object", ErrProblem)
+	}
+
+	var violations []string
+
+	for _, member := range []string{"type", "title", "detail", "instance"} {
+		value, exists := problem[member]
+		if !exists {
+			if member == "type" || member == "title" {
+				violations = append(violations, fmt.Sprintf("member %s is missing", member))
+			}
+
+			continue
+		}
+
+		if _, ok := value.(string); !ok {
+			violations = append(violations, fmt.Sprintf("member %s should be a string, got %v", member, value))
+		}
+	}
+
+	switch status, ok := problem["status"].(float64); {
+	case !ok:
+		violations = append(violations, fmt.Sprintf("member status should be a number, got %v", problem["status"]))
+	case int(status) != r.Status:
+		violations = append(violations, fmt.Sprintf("member status %v does not equal response status %d", status, r.Status))
+	}
+
+	if len(violations) > 0 {
+		return problem, fmt.Errorf("%w:\n  - %s", ErrProblem, strings.Join(violations, "\n  - "))
+	}
+
+	return problem, nil
+}
+
+// IsProblem asserts response is a valid problem details which members match
+// an optional `field | matcher | value` table. Extension members are matched
+// like standard ones.
+func (r Response) IsProblem(expected *godog.Table) error {
+	problem, err := r.Problem()
+	if err != nil {
+		return err
+	}
+
+	if expected == nil {
+		return nil
+	}
+
+	return FieldsMatch(problem, expected)
+}
+
+// ProblemErrorsContain asserts problem `errors` member lists an entry for
+// each table row. Table head lists compared members as `.` separated paths:
+//
+//	| pointer  | detail          |
+//	| #/email  | must be a email |
+//	| #/age    |                 |
+//
+// Empty cells are not checked. Non string values use type hints,
+// e.g. `12((float))`. Every missing entry is reported.
+func (r Response) ProblemErrorsContain(expected *godog.Table) error {
+	problem, err := r.Problem()
+	if err != nil {
+		return err
+	}
+
+	entries, ok := problem[problemErrors].([]interface{})
+	if !ok {
+		return fmt.Errorf("%w: member %s should be an array", ErrProblem, problemErrors)
+	}
+
+	head := expected.Rows[0].Cells
+
+	var missing []string
+
+	for i := 1; i < len(expected.Rows); i++ {
+		var description []string
+
+		values := make(map[string]string)
+
+		for n, cell := range expected.Rows[i].Cells {
+			if cell.Value != "" {
+				values[head[n].Value] = cell.Value
+				description = append(description, fmt.Sprintf("%s=%q", head[n].Value, cell.Value))
+			}
+		}
+
+		found := false
+
+		for _, entry := range entries {
+			if problemEntryMatches(entry, values) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			missing = append(missing, strings.Join(description, ", "))
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: no %s entry with:\n  - %s", ErrProblem, problemErrors, strings.Join(missing, "\n  - "))
+	}
+
+	return nil
+}
+
+func problemEntryMatches(entry interface{}, expected map[string]string) bool {
+	for field, want := range expected {
+		value, exists := interfaces.GetFieldFromPath(entry, field)
+		if !exists || match.Assert("equal", value, want) != nil {
+			return false
+		}
+	}
+
+	return true
+}
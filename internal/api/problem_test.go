@@ -0,0 +1,80 @@
+package api_test
+
+import (
+	"net/http"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/elmagician/kactus/internal/api"
+	match "github.com/elmagician/kactus/internal/matchers"
+	. "github.com/elmagician/kactus/internal/test"
+)
+
+func TestUnit_Response_Problem(t *testing.T) {
+	Convey("Given problem details responses", t, func() {
+		problem := func(status int, contentType, body string) *api.Response {
+			return api.NewResponse(status, []byte(body), nil, http.Header{"Content-Type": {contentType}})
+		}
+
+		valid := problem(http.StatusBadRequest, "application/problem+json; charset=utf-8", `{
+			"type": "https://example.test/probs/validation",
+			"title": "Invalid request",
+			"status": 400,
+			"detail": "2 fields are invalid",
+			"instance": "/users/42",
+			"trace_id": "abc",
+			"errors": [
+				{"pointer": "#/email", "detail": "must be an email"},
+				{"pointer": "#/age", "detail": "must be positive", "code": 12}
+			]
+		}`)
+
+		Convey("should accept valid problems", func() {
+			So(valid.IsProblem(nil), ShouldBeNil)
+			So(valid.IsProblem(NewTable(
+				[]string{"field", "matcher", "value"},
+				[]string{"title", "equal", "Invalid request"},
+				[]string{"detail", "contain", "invalid"},
+				[]string{"trace_id", "defined", ""},
+			)), ShouldBeNil)
+
+			So(valid.IsProblem(NewTable(
+				[]string{"field", "value"},
+				[]string{"title", "Server error"},
+			)), ShouldBeLikeError, match.ErrUnmatched)
+		})
+
+		Convey("should report every violation", func() {
+			err := problem(http.StatusNotFound, "application/problem+json", `{"title": 1, "status": 400, "detail": []}`).IsProblem(nil)
+			So(err, ShouldBeLikeError, api.ErrProblem)
+			So(err.Error(), ShouldContainSubstring, "type is missing")
+			So(err.Error(), ShouldContainSubstring, "title should be a string")
+			So(err.Error(), ShouldContainSubstring, "detail should be a string")
+			So(err.Error(), ShouldContainSubstring, "does not equal response status 404")
+
+			So(problem(http.StatusBadRequest, "application/json", `{}`).IsProblem(nil), ShouldBeLikeError, api.ErrContentType)
+			So(problem(http.StatusBadRequest, api.ProblemContentType, `[]`).IsProblem(nil), ShouldBeLikeError, api.ErrProblem)
+		})
+
+		Convey("should validate field errors", func() {
+			So(valid.ProblemErrorsContain(NewTable(
+				[]string{"pointer", "detail", "code"},
+				[]string{"#/email", "must be an email", ""},
+				[]string{"#/age", "", "12((float))"},
+			)), ShouldBeNil)
+
+			err := valid.ProblemErrorsContain(NewTable(
+				[]string{"pointer", "detail"},
+				[]string{"#/email", "must be positive"},
+				[]string{"#/name", ""},
+			))
+			So(err, ShouldBeLikeError, api.ErrProblem)
+			So(err.Error(), ShouldContainSubstring, `pointer="#/email", detail="must be positive"`)
+			So(err.Error(), ShouldContainSubstring, `pointer="#/name"`)
+
+			noErrors := problem(http.StatusBadRequest, api.ProblemContentType, `{"type": "about:blank", "title": "Bad", "status": 400}`)
+			So(noErrors.ProblemErrorsContain(NewTable([]string{"pointer"}, []string{"#/a"})), ShouldBeLikeError, api.ErrProblem)
+		})
+	})
+}
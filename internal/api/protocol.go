@@ -0,0 +1,132 @@
+package api
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"golang.org/x/net/http2"
+)
+
+// Supported protocols.
+const (
+	// ProtocolDefault negotiates HTTP/2 over TLS when available and uses HTTP/1.1 otherwise.
+	ProtocolDefault = "default"
+	// ProtocolHTTP1 forces HTTP/1.1.
+	ProtocolHTTP1 = "HTTP/1.1"
+	// ProtocolHTTP2 forces HTTP/2 over TLS.
+	ProtocolHTTP2 = "HTTP/2"
+	// ProtocolH2C uses cleartext HTTP/2 with prior knowledge.
+	ProtocolH2C = "h2c"
+)
+
+var (
+	// ErrUnsupportedProtocol is thrown when requesting an unknown protocol.
+	ErrUnsupportedProtocol = errors.New("unsupported protocol")
+	// ErrInvalidCertificate is thrown when trusted certificates cannot be loaded.
+	ErrInvalidCertificate = errors.New("invalid certificate")
+)
+
+// SetProtocol configures protocol used by client to emit requests.
+func (cli *Client) SetProtocol(protocol string) error {
+	var base http.RoundTripper
+
+	switch strings.ToLower(strings.TrimSpace(protocol)) {
+	case "", ProtocolDefault:
+		if cli.tlsConfig != nil {
+			transport := http.DefaultTransport.(*http.Transport).Clone()
+			transport.TLSClientConfig = cli.tlsConfig.Clone()
+			base = transport
+		}
+	case "http/1.1", "http/1", "http1":
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.ForceAttemptHTTP2 = false
+		transport.TLSClientConfig = cli.tlsConfig.Clone()
+		// a non nil empty map disables HTTP/2 upgrade.
+		transport.TLSNextProto = make(map[string]func(string, *tls.Conn) http.RoundTripper)
+		base = transport
+	case "http/2", "http2", "h2":
+		base = &http2.Transport{TLSClientConfig: cli.tlsConfig.Clone()}
+	case ProtocolH2C:
+		base = &http2.Transport{
+			AllowHTTP: true,
+			DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, network, addr)
+			},
+		}
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedProtocol, protocol)
+	}
+
+	cli.transport.setBase(base)
+	cli.protocol = protocol
+
+	return nil
+}
+
+// SetTLSConfig sets TLS configuration used by current and later selected
+// protocols, e.g. to reach servers using self-signed certificates.
+func (cli *Client) SetTLSConfig(config *tls.Config) error {
+	cli.tlsConfig = config
+
+	return cli.SetProtocol(cli.protocol)
+}
+
+// TrustCertificates adds PEM encoded certificate authorities to trusted ones.
+func (cli *Client) TrustCertificates(certificates []byte) error {
+	config := cli.currentTLSConfig()
+
+	if config.RootCAs == nil {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+
+		config.RootCAs = pool
+	} else {
+		config.RootCAs = config.RootCAs.Clone()
+	}
+
+	if !config.RootCAs.AppendCertsFromPEM(certificates) {
+		return fmt.Errorf("%w: no PEM certificate found", ErrInvalidCertificate)
+	}
+
+	return cli.SetTLSConfig(config)
+}
+
+// SetInsecureSkipVerify disables server certificate verification when skip is true.
+func (cli *Client) SetInsecureSkipVerify(skip bool) error {
+	config := cli.currentTLSConfig()
+	config.InsecureSkipVerify = skip // nolint: gosec
+
+	return cli.SetTLSConfig(config)
+}
+
+func (cli *Client) currentTLSConfig() *tls.Config {
+	if cli.tlsConfig == nil {
+		return &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	return cli.tlsConfig.Clone()
+}
+
+// NormalizeProtocol returns the response protocol version string
+// matching a protocol name, e.g. `h2` or `HTTP/2` gives `HTTP/2.0`.
+func NormalizeProtocol(protocol string) string {
+	switch upper := strings.ToUpper(strings.TrimSpace(protocol)); upper {
+	case "H2", "H2C", "HTTP/2", "HTTP2":
+		return "HTTP/2.0"
+	case "HTTP/1", "HTTP1", "HTTP1.1", "HTTP/1.1":
+		return ProtocolHTTP1
+	case "HTTP/1.0", "HTTP1.0":
+		return "HTTP/1.0"
+	default:
+		return upper
+	}
+}
@@ -0,0 +1,129 @@
+package api_test
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+
+	"github.com/elmagician/kactus/internal/api"
+	. "github.com/elmagician/kactus/internal/test"
+)
+
+func TestUnit_Client_SetProtocol(t *testing.T) {
+	Convey("Given HTTP servers", t, func() {
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(r.Proto)) // nolint: errcheck
+		})
+
+		tlsServer := httptest.NewUnstartedServer(handler)
+		tlsServer.EnableHTTP2 = true
+		tlsServer.StartTLS()
+		defer tlsServer.Close()
+
+		h2cServer := httptest.NewServer(h2c.NewHandler(handler, &http2.Server{}))
+		defer h2cServer.Close()
+
+		cli, err := api.NewClient(&http.Client{})
+		So(err, ShouldBeNil)
+
+		So(cli.SetTLSConfig(tlsServer.Client().Transport.(*http.Transport).TLSClientConfig), ShouldBeNil)
+
+		emit := func(protocol, url string) error {
+			So(cli.SetProtocol(protocol), ShouldBeNil)
+
+			return cli.EmitRequest(api.PrepareRequest(false).SetEndpoint(url))
+		}
+
+		Convey("should force HTTP/1.1 over TLS", func() {
+			So(emit("HTTP/1.1", tlsServer.URL), ShouldBeNil)
+			So(cli.Response.Protocol, ShouldEqual, "HTTP/1.1")
+			So(string(cli.Response.Body), ShouldEqual, "HTTP/1.1")
+		})
+
+		Convey("should force HTTP/2 over TLS", func() {
+			So(emit("HTTP/2", tlsServer.URL), ShouldBeNil)
+			So(cli.Response.Protocol, ShouldEqual, api.NormalizeProtocol("h2"))
+			So(string(cli.Response.Body), ShouldEqual, "HTTP/2.0")
+		})
+
+		Convey("should use h2c with prior knowledge", func() {
+			So(emit("h2c", h2cServer.URL), ShouldBeNil)
+			So(cli.Response.Protocol, ShouldEqual, "HTTP/2.0")
+		})
+
+		Convey("should fail HTTP/2 on cleartext endpoints", func() {
+			So(emit("HTTP/2", h2cServer.URL), ShouldNotBeNil)
+		})
+
+		Convey("should negotiate by default", func() {
+			So(emit("default", h2cServer.URL), ShouldBeNil)
+			So(cli.Response.Protocol, ShouldEqual, "HTTP/1.1")
+		})
+
+		Convey("should reject unknown protocols", func() {
+			So(cli.SetProtocol("spdy"), ShouldBeLikeError, api.ErrUnsupportedProtocol)
+		})
+	})
+}
+
+func TestUnit_Client_TLS(t *testing.T) {
+	Convey("Given a server using a self-signed certificate", t, func() {
+		server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(r.Proto)) // nolint: errcheck
+		}))
+		server.EnableHTTP2 = true
+		server.StartTLS()
+		defer server.Close()
+
+		cli, err := api.NewClient(&http.Client{})
+		So(err, ShouldBeNil)
+
+		req := api.PrepareRequest(false).SetEndpoint(server.URL)
+
+		Convey("should reject untrusted certificate", func() {
+			So(cli.EmitRequest(req), ShouldNotBeNil)
+		})
+
+		Convey("should trust provided certificate authority for any protocol", func() {
+			So(cli.TrustCertificates(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})), ShouldBeNil)
+
+			So(cli.EmitRequest(req), ShouldBeNil)
+			So(cli.Response.Protocol, ShouldEqual, "HTTP/2.0")
+
+			So(cli.SetProtocol("HTTP/1.1"), ShouldBeNil)
+			So(cli.EmitRequest(req), ShouldBeNil)
+			So(cli.Response.Protocol, ShouldEqual, "HTTP/1.1")
+
+			Convey("until client is reset", func() {
+				cli.Reset()
+				So(cli.EmitRequest(req), ShouldNotBeNil)
+			})
+		})
+
+		Convey("should skip verification", func() {
+			So(cli.SetProtocol("HTTP/2"), ShouldBeNil)
+			So(cli.SetInsecureSkipVerify(true), ShouldBeNil)
+
+			So(cli.EmitRequest(req), ShouldBeNil)
+			So(cli.Response.Protocol, ShouldEqual, "HTTP/2.0")
+		})
+
+		Convey("should reject invalid certificates", func() {
+			So(cli.TrustCertificates([]byte("not a certificate")), ShouldBeLikeError, api.ErrInvalidCertificate)
+		})
+	})
+}
+
+func TestUnit_NormalizeProtocol(t *testing.T) {
+	Convey("When I normalize protocol names", t, func() {
+		So(api.NormalizeProtocol("http/2"), ShouldEqual, "HTTP/2.0")
+		So(api.NormalizeProtocol("HTTP/2.0"), ShouldEqual, "HTTP/2.0")
+		So(api.NormalizeProtocol("h2c"), ShouldEqual, "HTTP/2.0")
+		So(api.NormalizeProtocol("http/1.1"), ShouldEqual, "HTTP/1.1")
+	})
+}
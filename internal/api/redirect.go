@@ -0,0 +1,173 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/cucumber/godog"
+
+	"github.com/elmagician/kactus/internal"
+)
+
+// maxRedirects is the number of hops followed before giving up, as done by net/http.
+const maxRedirects = 10
+
+var (
+	// ErrRedirect is thrown when redirection chain does not match expected.
+	ErrRedirect = errors.New("redirection does not match expected")
+	// ErrTooManyRedirects is thrown when following more than maxRedirects hops.
+	ErrTooManyRedirects = fmt.Errorf("stopped after %d redirects", maxRedirects)
+)
+
+// Redirect is a redirection hop followed by client.
+type Redirect struct {
+	Status   int
+	URL      string
+	Location string
+	Cookies  []*http.Cookie
+}
+
+// redirectChain lists hops followed to obtain response, oldest first.
+func redirectChain(response *http.Response) []Redirect {
+	var chain []Redirect
+
+	for req := response.Request; req != nil && req.Response != nil; req = req.Response.Request {
+		hop := Redirect{
+			Status:   req.Response.StatusCode,
+			Location: req.Response.Header.Get("Location"),
+			Cookies:  req.Response.Cookies(),
+		}
+
+		if req.Response.Request != nil {
+			hop.URL = req.Response.Request.URL.String()
+		}
+
+		chain = append([]Redirect{hop}, chain...)
+	}
+
+	return chain
+}
+
+// RedirectCountIs asserts number of redirections followed to obtain response.
+func (r Response) RedirectCountIs(count int) error {
+	if len(r.Redirects) != count {
+		return fmt.Errorf("%w: expected %d redirects, got %d", ErrRedirect, count, len(r.Redirects))
+	}
+
+	return nil
+}
+
+// RedirectChainMatches asserts redirections followed to obtain response
+// match a table, one row per hop in order:
+//
+//	| status | url             | location  | cookies        |
+//	| 302    | /login          | /auth     | session        |
+//	| 301    | http://host/old | /new      |                |
+//
+// Every column is optional. URLs starting with `/` are compared with URL
+// path and query only. cookies is a `, ` separated list of cookie names set
+// by hop.
+func (r Response) RedirectChainMatches(expected *godog.Table) error {
+	if len(expected.Rows)-1 != len(r.Redirects) {
+		return fmt.Errorf(
+			"%w: expected %d redirects, got %d", ErrRedirect, len(expected.Rows)-1, len(r.Redirects),
+		)
+	}
+
+	head := expected.Rows[0].Cells
+
+	for i := 1; i < len(expected.Rows); i++ {
+		hop := r.Redirects[i-1]
+
+		for n, cell := range expected.Rows[i].Cells {
+			var err error
+
+			switch head[n].Value {
+			case "status":
+				err = hopStatusIs(hop, cell.Value)
+			case "url":
+				err = urlIs(hop.URL, cell.Value)
+			case "location":
+				err = urlIs(hop.Location, cell.Value)
+			case "cookies":
+				err = hopSetsCookies(hop, cell.Value)
+			default:
+				return fmt.Errorf("%w %s", internal.ErrUnexpectedColumn, head[n].Value)
+			}
+
+			if err != nil {
+				return fmt.Errorf("redirect %d: %w", i, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// FinalURLIs asserts URL which answered response.
+func (r Response) FinalURLIs(expected string) error {
+	return urlIs(r.URL, expected)
+}
+
+// FinalURLQuery retrieves query parameter from URL which answered response.
+func (r Response) FinalURLQuery(key string) (string, error) {
+	parsed, err := url.Parse(r.URL)
+	if err != nil {
+		return "", err
+	}
+
+	values := parsed.Query()
+	if _, ok := values[key]; !ok {
+		return "", fmt.Errorf("%w: query parameter %s in %s", ErrUnknownKey, key, r.URL)
+	}
+
+	return values.Get(key), nil
+}
+
+func hopStatusIs(hop Redirect, expected string) error {
+	status, err := strconv.Atoi(expected)
+	if err != nil {
+		return fmt.Errorf("%w: status %s", ErrInvalidOption, expected)
+	}
+
+	if hop.Status != status {
+		return fmt.Errorf("%w: expected status %d, got %d", ErrRedirect, status, hop.Status)
+	}
+
+	return nil
+}
+
+func hopSetsCookies(hop Redirect, expected string) error {
+	set := make(map[string]bool, len(hop.Cookies))
+	for _, cookie := range hop.Cookies {
+		set[cookie.Name] = true
+	}
+
+	for _, name := range strings.Split(expected, ",") {
+		if name = strings.TrimSpace(name); name != "" && !set[name] {
+			return fmt.Errorf("%w: expected cookie %s to be set", ErrRedirect, name)
+		}
+	}
+
+	return nil
+}
+
+// urlIs compares URLs. Expected URLs starting with `/` are compared
+// with actual path and query only.
+func urlIs(actual, expected string) error {
+	if actual == expected {
+		return nil
+	}
+
+	if strings.HasPrefix(expected, "/") {
+		if parsed, err := url.Parse(actual); err == nil && parsed.RequestURI() == expected {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: expected url %s, got %s", ErrRedirect, expected, actual)
+}
@@ -0,0 +1,88 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/elmagician/kactus/internal/api"
+	. "github.com/elmagician/kactus/internal/test"
+)
+
+func TestUnit_Client_Redirects(t *testing.T) {
+	Convey("Given a redirecting server", t, func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/old":
+				http.Redirect(w, r, "/login?next=home", http.StatusMovedPermanently)
+			case "/login":
+				http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc"})
+				http.Redirect(w, r, "/home?code=42", http.StatusFound)
+			case "/loop":
+				http.Redirect(w, r, "/loop", http.StatusFound)
+			default:
+				w.WriteHeader(http.StatusOK)
+			}
+		}))
+		defer server.Close()
+
+		cli, err := api.NewClient(&http.Client{})
+		So(err, ShouldBeNil)
+
+		Convey("should record every hop when following", func() {
+			cli.SetFollowRedirection(true)
+			So(cli.EmitRequest(api.PrepareRequest(false).SetMethod(http.MethodGet).SetEndpoint(server.URL+"/old")), ShouldBeNil)
+
+			So(cli.Response.Status, ShouldEqual, http.StatusOK)
+			So(cli.Response.RedirectCountIs(2), ShouldBeNil)
+			So(cli.Response.RedirectCountIs(1), ShouldBeLikeError, api.ErrRedirect)
+			So(cli.Response.Redirects[0].URL, ShouldEqual, server.URL+"/old")
+			So(cli.Response.Redirects[1].Cookies, ShouldHaveLength, 1)
+
+			So(cli.Response.RedirectChainMatches(NewTable(
+				[]string{"status", "url", "location", "cookies"},
+				[]string{"301", server.URL + "/old", "/login?next=home", ""},
+				[]string{"302", "/login?next=home", "/home?code=42", "session"},
+			)), ShouldBeNil)
+
+			So(cli.Response.RedirectChainMatches(NewTable(
+				[]string{"status", "cookies"},
+				[]string{"302", ""},
+				[]string{"302", "token"},
+			)), ShouldBeLikeError, api.ErrRedirect)
+
+			So(cli.Response.RedirectChainMatches(NewTable(
+				[]string{"status"},
+				[]string{"301"},
+			)), ShouldBeLikeError, api.ErrRedirect)
+
+			So(cli.Response.FinalURLIs("/home?code=42"), ShouldBeNil)
+			So(cli.Response.FinalURLIs(server.URL+"/home?code=42"), ShouldBeNil)
+			So(cli.Response.FinalURLIs("/login"), ShouldBeLikeError, api.ErrRedirect)
+
+			code, err := cli.Response.FinalURLQuery("code")
+			So(err, ShouldBeNil)
+			So(code, ShouldEqual, "42")
+
+			_, err = cli.Response.FinalURLQuery("next")
+			So(err, ShouldBeLikeError, api.ErrUnknownKey)
+		})
+
+		Convey("should stop infinite redirections", func() {
+			cli.SetFollowRedirection(true)
+			err := cli.EmitRequest(api.PrepareRequest(false).SetMethod(http.MethodGet).SetEndpoint(server.URL + "/loop"))
+			So(err, ShouldBeLikeError, api.ErrTooManyRedirects)
+		})
+
+		Convey("should record no hop when not following", func() {
+			cli.SetFollowRedirection(false)
+			So(cli.EmitRequest(api.PrepareRequest(false).SetMethod(http.MethodGet).SetEndpoint(server.URL+"/old")), ShouldBeNil)
+
+			So(cli.Response.Status, ShouldEqual, http.StatusMovedPermanently)
+			So(cli.Response.RedirectCountIs(0), ShouldBeNil)
+			So(cli.Response.FinalURLIs("/old"), ShouldBeNil)
+		})
+	})
+}
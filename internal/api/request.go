@@ -3,6 +3,7 @@ package api
 import (
 	"bytes"
 	"io"
+	"io/ioutil"
 	"mime/multipart"
 	"net/http"
 	"os"
@@ -30,6 +31,13 @@ type (
 		Arguments   map[string]string
 		Endpoint    string
 		Method      string
+		Compression string
+		Timeout     time.Duration
+
+		// EncodedBody is a body already encoded by a Codec, sent
+		// using EncodedType Content-Type.
+		EncodedBody []byte
+		EncodedType string
 	}
 
 	formElement struct {
@@ -58,6 +66,12 @@ func (request RequestPreparation) SetMethod(method string) RequestPreparation {
 	return request
 }
 
+// SetTimeout sets request timeout, overriding client default timeout.
+func (request RequestPreparation) SetTimeout(timeout time.Duration) RequestPreparation {
+	request.Timeout = timeout
+	return request
+}
+
 func (request RequestPreparation) SetEndpoint(endpoint string) RequestPreparation {
 	request.Endpoint = endpoint
 	return request
@@ -72,6 +86,21 @@ func (request RequestPreparation) SetJSONBody(body *godog.DocString) RequestPrep
 	return request
 }
 
+// SetEncodedBody encodes a JSON document using codec (msgpack, cbor, protobuf)
+// and sets it as request body.
+func (request RequestPreparation) SetEncodedBody(codec Codec, body *godog.DocString) (RequestPreparation, error) {
+	encoded, err := EncodeJSON(codec, body.Content)
+	if err != nil {
+		return request, err
+	}
+
+	request.JSONBody = nil
+	request.EncodedBody = encoded
+	request.EncodedType = codec.ContentType()
+
+	return request, nil
+}
+
 func (request RequestPreparation) SetFORMBody(body *godog.Table) RequestPreparation {
 	var key, val, kind string
 
@@ -109,9 +138,19 @@ func (request RequestPreparation) SetFORMBody(body *godog.Table) RequestPreparat
 	return request
 }
 
+// SetCompression compresses request body using algorithm (gzip, deflate, br or zstd)
+// and sets matching Content-Encoding header.
+func (request RequestPreparation) SetCompression(algorithm string) RequestPreparation {
+	request.Compression = algorithm
+	return request
+}
+
 func (request RequestPreparation) ResetBody() RequestPreparation {
 	request.JSONBody = nil
 	request.FORMBody = nil
+	request.EncodedBody = nil
+	request.EncodedType = ""
+	request.Compression = ""
 
 	return request
 }
@@ -267,6 +306,13 @@ func (request RequestPreparation) GenerateRequest(jar http.CookieJar) (*http.Req
 		contentType = "application/json"
 	}
 
+	if request.JSONBody == nil && request.EncodedBody != nil {
+		hasBody = true
+		body = string(request.EncodedBody)
+
+		contentType = request.EncodedType
+	}
+
 	if body == "" && request.FORMBody != nil {
 		hasForm = true
 		hasBody = true
@@ -320,12 +366,22 @@ func (request RequestPreparation) GenerateRequest(jar http.CookieJar) (*http.Req
 		return nil, err
 	}
 
+	var payload io.Reader = strings.NewReader(body)
 	if hasForm {
-		req, err = http.NewRequest(request.Method, request.Endpoint, &b)
-	} else {
-		req, err = http.NewRequest(request.Method, request.Endpoint, strings.NewReader(body))
+		payload = &b
 	}
 
+	var contentEncoding string
+
+	if hasBody && request.Compression != "" {
+		if payload, err = compressPayload(request.Compression, payload); err != nil {
+			return nil, err
+		}
+
+		contentEncoding = NormalizeEncoding(request.Compression)
+	}
+
+	req, err = http.NewRequest(request.Method, request.Endpoint, payload)
 	if err != nil {
 		return nil, err
 	}
@@ -344,9 +400,29 @@ func (request RequestPreparation) GenerateRequest(jar http.CookieJar) (*http.Req
 
 	req.Header = *request.Headers
 
+	if contentEncoding != "" {
+		// preparation headers are shared, encoding only applies to this body.
+		req.Header = req.Header.Clone()
+		req.Header.Set("Content-Encoding", contentEncoding)
+	}
+
 	return req, nil
 }
 
+func compressPayload(algorithm string, payload io.Reader) (io.Reader, error) {
+	raw, err := ioutil.ReadAll(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	compressed, err := Compress(algorithm, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.NewReader(compressed), nil
+}
+
 func formElementKindFromString(kind string) formElementKind {
 	switch kind {
 	case "file":
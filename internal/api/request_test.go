@@ -9,7 +9,7 @@ import (
 
 	fake "github.com/brianvoe/gofakeit/v5"
 	"github.com/cucumber/godog"
-	"github.com/cucumber/messages-go/v10"
+	messages "github.com/cucumber/messages/go/v21"
 	. "github.com/smartystreets/goconvey/convey"
 
 	"github.com/elmagician/kactus/internal/api"
@@ -92,21 +92,21 @@ func TestUnit_RequestPreparation_SetFORMBody(t *testing.T) {
 		value2 := "value2"
 
 		body := &godog.Table{
-			Rows: []*messages.PickleStepArgument_PickleTable_PickleTableRow{
+			Rows: []*messages.PickleTableRow{
 				{
-					Cells: []*messages.PickleStepArgument_PickleTable_PickleTableRow_PickleTableCell{
+					Cells: []*messages.PickleTableCell{
 						{Value: "key"},
 						{Value: "value"},
 						{Value: "kind"},
 					},
 				}, {
-					Cells: []*messages.PickleStepArgument_PickleTable_PickleTableRow_PickleTableCell{
+					Cells: []*messages.PickleTableCell{
 						{Value: key1},
 						{Value: value1},
 						{Value: kind1},
 					},
 				}, {
-					Cells: []*messages.PickleStepArgument_PickleTable_PickleTableRow_PickleTableCell{
+					Cells: []*messages.PickleTableCell{
 						{Value: key2},
 						{Value: value2},
 						{Value: kind2},
@@ -195,10 +195,10 @@ func TestUnit_RequestPreparation_AddCookie(t *testing.T) {
 		sameSite := 15
 		httpOnly := fake.Bool()
 		unparsed := "unparsed"
-		options := &messages.PickleStepArgument_PickleTable{
-			Rows: []*messages.PickleStepArgument_PickleTable_PickleTableRow{
+		options := &messages.PickleTable{
+			Rows: []*messages.PickleTableRow{
 				{
-					Cells: []*messages.PickleStepArgument_PickleTable_PickleTableRow_PickleTableCell{
+					Cells: []*messages.PickleTableCell{
 						{Value: "path"},
 						{Value: "domain"},
 						{Value: "expires"},
@@ -212,7 +212,7 @@ func TestUnit_RequestPreparation_AddCookie(t *testing.T) {
 						{Value: "unknown"},
 					},
 				}, {
-					Cells: []*messages.PickleStepArgument_PickleTable_PickleTableRow_PickleTableCell{
+					Cells: []*messages.PickleTableCell{
 						{Value: path},
 						{Value: domain},
 						{Value: strconv.Itoa(int(expires))},
@@ -2,7 +2,6 @@ package api
 
 import (
 	"bytes"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -24,6 +23,25 @@ type Response struct {
 	Headers http.Header
 	Body    []byte
 	Cookies map[string]*http.Cookie
+
+	// ContentEncoding is the encoding used by server to send body.
+	// Body is always decoded. Downloads are only decoded from gzip.
+	ContentEncoding string
+
+	// Protocol is the protocol version used by server to answer, e.g. HTTP/2.0.
+	Protocol string
+
+	// URL is the URL which answered, after following redirections.
+	URL string
+	// Redirects lists redirections followed to obtain response, oldest first.
+	Redirects []Redirect
+
+	// Download is set instead of Body when body was streamed to a file.
+	Download *Download
+
+	// Codec decodes body. When nil, codec is chosen from Content-Type
+	// and defaults to JSON.
+	Codec Codec
 }
 
 func NewResponse(status int, body []byte, cookies []*http.Cookie, headers http.Header) *Response {
@@ -42,12 +60,12 @@ func NewResponse(status int, body []byte, cookies []*http.Cookie, headers http.H
 }
 
 func (r Response) RetrieveJSON(key string) (interface{}, error) {
-	if r.HasEmptyBody() {
-		return nil, ErrNoBody
+	if err := r.bodyError(); err != nil {
+		return nil, err
 	}
-	var body interface{}
 
-	if err := json.Unmarshal(r.Body, &body); err != nil {
+	body, err := r.Decode()
+	if err != nil {
 		return nil, err
 	}
 
@@ -56,16 +74,30 @@ func (r Response) RetrieveJSON(key string) (interface{}, error) {
 		return nil, fmt.Errorf("%w: %s", ErrUnknownKey, key)
 	}
 
+	if !val.IsValid() { // JSON null
+		return nil, nil
+	}
+
 	return val.Interface(), nil
 }
 
+// Decode decodes body to the generic tree produced by JSON decoding
+// using response codec.
+func (r Response) Decode() (interface{}, error) {
+	if err := r.streamedError(); err != nil {
+		return nil, err
+	}
+
+	return decodeBody(r.Body, r.Codec, r.Headers.Get("Content-Type"))
+}
+
 func (r Response) RetrieveHeader(key string) string {
 	return r.Headers.Get(key)
 }
 
 func (r Response) RetrieveHTMLAttribute(tag, attribute string, filters *godog.Table) (string, error) {
-	if r.HasEmptyBody() {
-		return "", ErrNoBody
+	if err := r.bodyError(); err != nil {
+		return "", err
 	}
 
 	var key, val, matcher, candidate string
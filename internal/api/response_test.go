@@ -6,7 +6,7 @@ import (
 
 	fake "github.com/brianvoe/gofakeit/v5"
 	"github.com/cucumber/godog"
-	"github.com/cucumber/messages-go/v10"
+	messages "github.com/cucumber/messages/go/v21"
 	. "github.com/smartystreets/goconvey/convey"
 
 	"github.com/elmagician/kactus/internal/api"
@@ -61,6 +61,18 @@ func TestUnit_Response_RetrieveJSON(t *testing.T) {
 			So(v, ShouldEqual, expectedValue)
 		})
 
+		Convey("should retrieve null values", func() {
+			r.Body = []byte(`{"foo": null, "list": [null]}`)
+
+			v, err := r.RetrieveJSON(key)
+			So(err, ShouldBeNil)
+			So(v, ShouldBeNil)
+
+			v, err = r.RetrieveJSON("list.0")
+			So(err, ShouldBeNil)
+			So(v, ShouldBeNil)
+		})
+
 		Convey("Should fail", func() {
 			Convey("if empty body", func() {
 				r.Body = nil
@@ -98,16 +110,16 @@ func TestUnit_Response_RetrieveHTMLAttribute(t *testing.T) {
 	Convey("When I try to retrieve HTML attribute", t, func() {
 		tag, attribute := "p", "id"
 		filters := &godog.Table{
-			Rows: []*messages.PickleStepArgument_PickleTable_PickleTableRow{
+			Rows: []*messages.PickleTableRow{
 				{
-					Cells: []*messages.PickleStepArgument_PickleTable_PickleTableRow_PickleTableCell{
+					Cells: []*messages.PickleTableCell{
 						{Value: "attribute"},
 						{Value: "value"},
 						{Value: "match"},
 					},
 				},
 				{
-					Cells: []*messages.PickleStepArgument_PickleTable_PickleTableRow_PickleTableCell{
+					Cells: []*messages.PickleTableCell{
 						{Value: "class"},
 						{Value: "SomeClass"},
 						{Value: "contain"},
@@ -124,9 +136,9 @@ func TestUnit_Response_RetrieveHTMLAttribute(t *testing.T) {
 
 		Convey("should success", func() {
 			Convey("without filters", func() {
-				filters.Rows = []*messages.PickleStepArgument_PickleTable_PickleTableRow{
+				filters.Rows = []*messages.PickleTableRow{
 					{
-						Cells: []*messages.PickleStepArgument_PickleTable_PickleTableRow_PickleTableCell{
+						Cells: []*messages.PickleTableCell{
 							{Value: "attribute"},
 							{Value: "value"},
 							{Value: "match"},
@@ -0,0 +1,221 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrSecurityHeaders is thrown when response headers violate a security policy.
+var ErrSecurityHeaders = errors.New("security header policy violated")
+
+// versionPattern detects software versions leaked by headers such as Server.
+var versionPattern = regexp.MustCompile(`\d+(\.\d+)+|/\d`)
+
+// SecurityPolicy describes security headers expected on responses.
+type SecurityPolicy struct {
+	// HSTSMinAge is the minimum Strict-Transport-Security max-age in seconds.
+	// Zero does not require HSTS.
+	HSTSMinAge int
+	// RequireCSP requires a Content-Security-Policy header.
+	RequireCSP bool
+	// AllowUnsafeInline allows 'unsafe-inline' CSP sources.
+	AllowUnsafeInline bool
+	// NoSniff requires `X-Content-Type-Options: nosniff`.
+	NoSniff bool
+	// FrameOptions lists accepted X-Frame-Options values. A CSP frame-ancestors
+	// directive is accepted too. Empty does not check framing.
+	FrameOptions []string
+	// ReferrerPolicies lists accepted Referrer-Policy values.
+	// Empty does not check referrer policy.
+	ReferrerPolicies []string
+	// AllowServerVersion allows Server and X-Powered-By headers to expose versions.
+	AllowServerVersion bool
+}
+
+// DefaultSecurityPolicy returns a strict policy suitable for browser facing endpoints.
+func DefaultSecurityPolicy() SecurityPolicy {
+	return SecurityPolicy{
+		HSTSMinAge:       15552000, // 180 days
+		RequireCSP:       true,
+		NoSniff:          true,
+		FrameOptions:     []string{"DENY", "SAMEORIGIN"},
+		ReferrerPolicies: []string{"no-referrer", "same-origin", "strict-origin", "strict-origin-when-cross-origin"},
+	}
+}
+
+// APISecurityPolicy returns a policy for JSON APIs which are not rendered by
+// browsers: CSP, framing and referrer checks are disabled.
+func APISecurityPolicy() SecurityPolicy {
+	return SecurityPolicy{HSTSMinAge: DefaultSecurityPolicy().HSTSMinAge, NoSniff: true}
+}
+
+// NewSecurityPolicy builds a policy from DefaultSecurityPolicy overridden by options:
+//
+//	| key               | value                                 |
+//	| hsts_max_age      | minimum seconds, 0 to disable         |
+//	| csp               | required or optional                  |
+//	| csp_unsafe_inline | allow or forbid                       |
+//	| nosniff           | true or false                         |
+//	| frame_options     | accepted values (`, ` separated)      |
+//	| referrer_policy   | accepted values (`, ` separated)      |
+//	| server_version    | allow or forbid                       |
+//
+// Empty frame_options or referrer_policy disables their check.
+func NewSecurityPolicy(options map[string]string) (SecurityPolicy, error) {
+	policy := DefaultSecurityPolicy()
+
+	for key, value := range options {
+		var err error
+
+		switch key {
+		case "hsts_max_age":
+			policy.HSTSMinAge, err = strconv.Atoi(value)
+		case "csp":
+			policy.RequireCSP, err = choice(value, "required", "optional")
+		case "csp_unsafe_inline":
+			policy.AllowUnsafeInline, err = choice(value, "allow", "forbid")
+		case "nosniff":
+			policy.NoSniff, err = strconv.ParseBool(value)
+		case "frame_options":
+			policy.FrameOptions = splitList(value)
+		case "referrer_policy":
+			policy.ReferrerPolicies = splitList(value)
+		case "server_version":
+			policy.AllowServerVersion, err = choice(value, "allow", "forbid")
+		default:
+			return policy, fmt.Errorf("%w: unknown security policy option %s", ErrInvalidOption, key)
+		}
+
+		if err != nil {
+			return policy, fmt.Errorf("%w: %s %s", ErrInvalidOption, key, value)
+		}
+	}
+
+	return policy, nil
+}
+
+// SecurityViolations lists every policy violation of response headers.
+func (r Response) SecurityViolations(policy SecurityPolicy) []string { // nolint: gocyclo
+	var violations []string
+
+	if policy.HSTSMinAge > 0 {
+		if age, ok := hstsMaxAge(r.Headers.Get("Strict-Transport-Security")); !ok {
+			violations = append(violations, "Strict-Transport-Security is missing or has no max-age")
+		} else if age < policy.HSTSMinAge {
+			violations = append(violations, fmt.Sprintf(
+				"Strict-Transport-Security max-age %d is lower than %d", age, policy.HSTSMinAge,
+			))
+		}
+	}
+
+	csp := strings.Join(r.Headers.Values("Content-Security-Policy"), "; ")
+
+	if policy.RequireCSP && csp == "" {
+		violations = append(violations, "Content-Security-Policy is missing")
+	}
+
+	if !policy.AllowUnsafeInline && strings.Contains(csp, "'unsafe-inline'") {
+		violations = append(violations, "Content-Security-Policy allows 'unsafe-inline'")
+	}
+
+	if policy.NoSniff && !strings.EqualFold(r.Headers.Get("X-Content-Type-Options"), "nosniff") {
+		violations = append(violations, "X-Content-Type-Options should be nosniff")
+	}
+
+	if len(policy.FrameOptions) > 0 && !strings.Contains(csp, "frame-ancestors") &&
+		!containsFold(policy.FrameOptions, r.Headers.Get("X-Frame-Options")) {
+		violations = append(violations, fmt.Sprintf(
+			"X-Frame-Options %q should be one of %s", r.Headers.Get("X-Frame-Options"), strings.Join(policy.FrameOptions, ", "),
+		))
+	}
+
+	if len(policy.ReferrerPolicies) > 0 && !referrerAllowed(policy.ReferrerPolicies, r.Headers.Get("Referrer-Policy")) {
+		violations = append(violations, fmt.Sprintf(
+			"Referrer-Policy %q should be one of %s", r.Headers.Get("Referrer-Policy"), strings.Join(policy.ReferrerPolicies, ", "),
+		))
+	}
+
+	if !policy.AllowServerVersion {
+		for _, name := range []string{"Server", "X-Powered-By", "X-AspNet-Version"} {
+			if value := r.Headers.Get(name); versionPattern.MatchString(value) {
+				violations = append(violations, fmt.Sprintf("%s leaks version %q", name, value))
+			}
+		}
+	}
+
+	return violations
+}
+
+// FollowsSecurityPolicy asserts response headers follow policy.
+// Every violation is reported.
+func (r Response) FollowsSecurityPolicy(policy SecurityPolicy) error {
+	violations := r.SecurityViolations(policy)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%w:\n  - %s", ErrSecurityHeaders, strings.Join(violations, "\n  - "))
+}
+
+func hstsMaxAge(header string) (int, bool) {
+	for _, directive := range strings.Split(header, ";") {
+		name, value, found := strings.Cut(strings.TrimSpace(directive), "=")
+		if !found || !strings.EqualFold(name, "max-age") {
+			continue
+		}
+
+		age, err := strconv.Atoi(strings.Trim(value, `"`))
+
+		return age, err == nil
+	}
+
+	return 0, false
+}
+
+// referrerAllowed checks the last supported token of Referrer-Policy,
+// browsers ignoring unknown tokens before it.
+func referrerAllowed(allowed []string, header string) bool {
+	tokens := splitList(header)
+	if len(tokens) == 0 {
+		return false
+	}
+
+	return containsFold(allowed, tokens[len(tokens)-1])
+}
+
+func containsFold(list []string, value string) bool {
+	for _, candidate := range list {
+		if strings.EqualFold(candidate, strings.TrimSpace(value)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func splitList(value string) []string {
+	var list []string
+
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+
+	return list
+}
+
+// choice parses value as yes when it equals yes, no when it equals no.
+func choice(value, yes, no string) (bool, error) {
+	switch value {
+	case yes:
+		return true, nil
+	case no:
+		return false, nil
+	default:
+		return false, ErrInvalidOption
+	}
+}
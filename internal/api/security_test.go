@@ -0,0 +1,72 @@
+package api_test
+
+import (
+	"net/http"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/elmagician/kactus/internal/api"
+	. "github.com/elmagician/kactus/internal/test"
+)
+
+func TestUnit_Response_FollowsSecurityPolicy(t *testing.T) {
+	Convey("Given security header policies", t, func() {
+		Convey("should accept a compliant response", func() {
+			response := api.NewResponse(http.StatusOK, nil, nil, http.Header{
+				"Strict-Transport-Security": {"max-age=31536000; includeSubDomains"},
+				"Content-Security-Policy":   {"default-src 'self'"},
+				"X-Content-Type-Options":    {"nosniff"},
+				"X-Frame-Options":           {"deny"},
+				"Referrer-Policy":           {"unsafe-url, strict-origin-when-cross-origin"},
+				"Server":                    {"nginx"},
+			})
+
+			So(response.FollowsSecurityPolicy(api.DefaultSecurityPolicy()), ShouldBeNil)
+		})
+
+		Convey("should report every violation", func() {
+			response := api.NewResponse(http.StatusOK, nil, nil, http.Header{
+				"Strict-Transport-Security": {"max-age=300"},
+				"Content-Security-Policy":   {"script-src 'self' 'unsafe-inline'"},
+				"Server":                    {"Apache/2.4.1 (Unix)"},
+			})
+
+			violations := response.SecurityViolations(api.DefaultSecurityPolicy())
+			So(violations, ShouldHaveLength, 6)
+
+			err := response.FollowsSecurityPolicy(api.DefaultSecurityPolicy())
+			So(err, ShouldBeLikeError, api.ErrSecurityHeaders)
+			So(err.Error(), ShouldContainSubstring, "max-age 300")
+			So(err.Error(), ShouldContainSubstring, "unsafe-inline")
+			So(err.Error(), ShouldContainSubstring, "nosniff")
+			So(err.Error(), ShouldContainSubstring, "X-Frame-Options")
+			So(err.Error(), ShouldContainSubstring, "Referrer-Policy")
+			So(err.Error(), ShouldContainSubstring, "Apache/2.4.1")
+
+			So(response.SecurityViolations(api.APISecurityPolicy()), ShouldHaveLength, 4)
+		})
+
+		Convey("should build policies from options", func() {
+			policy, err := api.NewSecurityPolicy(map[string]string{
+				"hsts_max_age":      "0",
+				"csp":               "optional",
+				"csp_unsafe_inline": "allow",
+				"nosniff":           "false",
+				"frame_options":     "",
+				"referrer_policy":   "",
+				"server_version":    "allow",
+			})
+			So(err, ShouldBeNil)
+
+			response := api.NewResponse(http.StatusOK, nil, nil, http.Header{"Server": {"Apache/2.4.1"}})
+			So(response.FollowsSecurityPolicy(policy), ShouldBeNil)
+
+			_, err = api.NewSecurityPolicy(map[string]string{"csp": "maybe"})
+			So(err, ShouldBeLikeError, api.ErrInvalidOption)
+
+			_, err = api.NewSecurityPolicy(map[string]string{"cookies": "secure"})
+			So(err, ShouldBeLikeError, api.ErrInvalidOption)
+		})
+	})
+}
@@ -0,0 +1,162 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrUnknownSession is thrown when restoring a session which was not saved.
+var ErrUnknownSession = errors.New("unknown cookie session")
+
+// sessionJar is a cookie jar recording cookies it receives so that its
+// content can be saved and replayed in a new jar.
+type sessionJar struct {
+	*cookiejar.Jar
+
+	mu      sync.Mutex
+	entries map[cookieKey]jarEntry
+}
+
+// cookieKey identifies a cookie: a newer cookie with the same key replaces older one.
+type cookieKey struct {
+	name   string
+	domain string
+	path   string
+}
+
+type jarEntry struct {
+	url    *url.URL
+	cookie *http.Cookie
+}
+
+func newSessionJar(entries []jarEntry) (*sessionJar, error) {
+	jar, err := cookiejar.New(&cookiejar.Options{PublicSuffixList: nil})
+	if err != nil {
+		return nil, err
+	}
+
+	session := &sessionJar{Jar: jar}
+
+	for _, entry := range entries {
+		session.SetCookies(entry.url, []*http.Cookie{entry.cookie})
+	}
+
+	return session, nil
+}
+
+// SetCookies stores cookies in jar and records them.
+// Max-Age is recorded as an absolute expiration so replaying keeps expiry.
+// Expired cookies delete recorded ones.
+func (jar *sessionJar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	jar.Jar.SetCookies(u, cookies)
+
+	copiedURL := *u
+	now := time.Now()
+
+	jar.mu.Lock()
+	defer jar.mu.Unlock()
+
+	if jar.entries == nil {
+		jar.entries = make(map[cookieKey]jarEntry)
+	}
+
+	for _, cookie := range cookies {
+		copied := *cookie
+		if copied.MaxAge > 0 {
+			copied.Expires = now.Add(time.Duration(copied.MaxAge) * time.Second)
+			copied.MaxAge = 0
+		}
+
+		key := newCookieKey(&copiedURL, &copied)
+
+		if copied.MaxAge < 0 || (!copied.Expires.IsZero() && !copied.Expires.After(now)) {
+			delete(jar.entries, key)
+			continue
+		}
+
+		jar.entries[key] = jarEntry{url: &copiedURL, cookie: &copied}
+	}
+}
+
+// snapshot returns recorded cookies which did not expire yet.
+func (jar *sessionJar) snapshot() []jarEntry {
+	jar.mu.Lock()
+	defer jar.mu.Unlock()
+
+	now := time.Now()
+	entries := make([]jarEntry, 0, len(jar.entries))
+
+	for _, entry := range jar.entries {
+		if entry.cookie.Expires.IsZero() || entry.cookie.Expires.After(now) {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries
+}
+
+// newCookieKey identifies cookie by name, domain and path, defaulting
+// to URL host and directory as cookie jars do.
+func newCookieKey(u *url.URL, cookie *http.Cookie) cookieKey {
+	domain := strings.ToLower(strings.TrimPrefix(cookie.Domain, "."))
+	if domain == "" {
+		domain = strings.ToLower(u.Hostname())
+	}
+
+	path := cookie.Path
+	if path == "" || path[0] != '/' {
+		path = "/"
+
+		if i := strings.LastIndex(u.Path, "/"); i > 0 {
+			path = u.Path[:i]
+		}
+	}
+
+	return cookieKey{name: cookie.Name, domain: domain, path: path}
+}
+
+// SaveSession saves client cookies as a named session.
+// Sessions are kept on Reset so they can be restored in later scenarios.
+func (cli *Client) SaveSession(name string) error {
+	jar, ok := cli.client.Jar.(*sessionJar)
+	if !ok {
+		return fmt.Errorf("%w: client jar cannot be saved", ErrUnknownSession)
+	}
+
+	if cli.sessions == nil {
+		cli.sessions = make(map[string][]jarEntry)
+	}
+
+	cli.sessions[name] = jar.snapshot()
+
+	return nil
+}
+
+// RestoreSession replaces client cookies by a saved session.
+// Cookies received afterward do not alter saved session.
+func (cli *Client) RestoreSession(name string) error {
+	entries, ok := cli.sessions[name]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownSession, name)
+	}
+
+	jar, err := newSessionJar(entries)
+	if err != nil {
+		return err
+	}
+
+	cli.client.Jar = jar
+
+	return nil
+}
+
+// ForgetSession deletes a saved session.
+func (cli *Client) ForgetSession(name string) {
+	delete(cli.sessions, name)
+}
@@ -0,0 +1,82 @@
+package api_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/elmagician/kactus/internal/api"
+	. "github.com/elmagician/kactus/internal/test"
+)
+
+func TestUnit_Client_Sessions(t *testing.T) {
+	Convey("Given a server with authentication cookies", t, func() {
+		logins := 0
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/login":
+				http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc", Path: "/", MaxAge: 3600})
+			case "/relogin":
+				logins++
+				http.SetCookie(w, &http.Cookie{Name: "session", Value: fmt.Sprint("login-", logins), Path: "/", MaxAge: 3600})
+			case "/logout":
+				http.SetCookie(w, &http.Cookie{Name: "session", Path: "/", MaxAge: -1})
+			default:
+				if cookie, err := r.Cookie("session"); err == nil {
+					w.Header().Set("X-Session", cookie.Value)
+				}
+			}
+		}))
+		defer server.Close()
+
+		cli, err := api.NewClient(&http.Client{})
+		So(err, ShouldBeNil)
+
+		get := func(path string) string {
+			So(cli.EmitRequest(api.PrepareRequest(false).SetMethod(http.MethodGet).SetEndpoint(server.URL+path)), ShouldBeNil)
+			return cli.Response.Headers.Get("X-Session")
+		}
+
+		get("/login")
+		So(cli.SaveSession("user"), ShouldBeNil)
+
+		Convey("should restore session after reset", func() {
+			cli.Reset()
+			So(get("/me"), ShouldBeEmpty)
+
+			So(cli.RestoreSession("user"), ShouldBeNil)
+			So(get("/me"), ShouldEqual, "abc")
+
+			Convey("and keep saved session untouched", func() {
+				get("/logout")
+				So(get("/me"), ShouldBeEmpty)
+
+				So(cli.RestoreSession("user"), ShouldBeNil)
+				So(get("/me"), ShouldEqual, "abc")
+			})
+		})
+
+		Convey("should save latest cookies only", func() {
+			get("/relogin")
+			get("/relogin")
+			So(cli.SaveSession("user"), ShouldBeNil)
+
+			cookies := api.SessionCookies(cli, "user")
+			So(cookies, ShouldHaveLength, 1)
+			So(cookies[0].Value, ShouldEqual, "login-2")
+
+			get("/logout")
+			So(cli.SaveSession("user"), ShouldBeNil)
+			So(api.SessionCookies(cli, "user"), ShouldBeEmpty)
+		})
+
+		Convey("should fail on unknown sessions", func() {
+			cli.ForgetSession("user")
+			So(cli.RestoreSession("user"), ShouldBeLikeError, api.ErrUnknownSession)
+		})
+	})
+}
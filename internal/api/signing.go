@@ -0,0 +1,557 @@
+package api
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha1" // nolint: gosec
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cucumber/godog"
+
+	"github.com/elmagician/kactus/internal"
+)
+
+const (
+	defaultSignatureHeader = "X-Signature"
+	defaultTimestampHeader = "X-Timestamp"
+
+	awsAlgorithm  = "AWS4-HMAC-SHA256"
+	awsDateFormat = "20060102T150405Z"
+	awsDayFormat  = "20060102"
+)
+
+var (
+	// ErrMissingOption is thrown when a mandatory signing option is not provided.
+	ErrMissingOption = errors.New("missing mandatory option")
+	// ErrInvalidOption is thrown when a signing option has an unexpected value.
+	ErrInvalidOption = errors.New("invalid option value")
+	// ErrInvalidSignature is thrown when a verifier cannot match a request signature.
+	ErrInvalidSignature = errors.New("invalid request signature")
+)
+
+type (
+	// Signer signs a generated request before it is emitted.
+	Signer interface {
+		Sign(req *http.Request) error
+	}
+
+	// Verifier checks a received request was signed by a matching Signer.
+	Verifier interface {
+		Verify(req *http.Request) error
+	}
+
+	// HMACSigner signs requests using an HMAC over a configurable canonical string.
+	//
+	// Canonical string is built by joining Components values with Separator.
+	// Known components are:
+	//	method: request method
+	//	path: escaped request path
+	//	query: raw request query
+	//	body: raw request body
+	//	body-hash: hex encoded SHA-256 of request body
+	//	timestamp: value of TimestampHeader, set to current unix time if missing
+	//	header:<name>: value of request header <name>
+	HMACSigner struct {
+		Secret          []byte
+		Algorithm       string
+		Components      []string
+		Separator       string
+		Header          string
+		Prefix          string
+		TimestampHeader string
+		Encoding        string
+
+		now func() time.Time
+	}
+
+	// AWSV4Signer signs requests using AWS Signature Version 4.
+	AWSV4Signer struct {
+		AccessKey    string
+		SecretKey    string
+		SessionToken string
+		Region       string
+		Service      string
+
+		now func() time.Time
+	}
+)
+
+// OptionsFromTable converts a `key | value` godog table to a map.
+func OptionsFromTable(table *godog.Table) (map[string]string, error) {
+	var key, value string
+
+	options := make(map[string]string)
+
+	if table == nil || len(table.Rows) == 0 {
+		return options, nil
+	}
+
+	head := table.Rows[0].Cells
+
+	for i := 1; i < len(table.Rows); i++ {
+		for n, cell := range table.Rows[i].Cells {
+			switch head[n].Value {
+			case "key", "option":
+				key = cell.Value
+			case valueHeader:
+				value = cell.Value
+			default:
+				return nil, fmt.Errorf("%w %s", internal.ErrUnexpectedColumn, head[n].Value)
+			}
+		}
+
+		options[key] = value
+
+		key = ""
+		value = ""
+	}
+
+	return options, nil
+}
+
+// NewHMACSigner initializes an HMACSigner from options.
+// Secret is read from `secret` option or from environment variable named by `secret_env`.
+func NewHMACSigner(options map[string]string) (*HMACSigner, error) {
+	secret, err := optionOrEnv(options, "secret")
+	if err != nil {
+		return nil, err
+	}
+
+	signer := &HMACSigner{
+		Secret:          []byte(secret),
+		Algorithm:       withDefault(options["algorithm"], "sha256"),
+		Components:      []string{"method", "path", "body-hash", "timestamp"},
+		Separator:       "\n",
+		Header:          withDefault(options["header"], defaultSignatureHeader),
+		Prefix:          options["prefix"],
+		TimestampHeader: withDefault(options["timestamp_header"], defaultTimestampHeader),
+		Encoding:        withDefault(options["encoding"], "hex"),
+		now:             time.Now,
+	}
+
+	if components, ok := options["components"]; ok {
+		signer.Components = nil
+
+		for _, component := range strings.Split(components, ",") {
+			signer.Components = append(signer.Components, strings.TrimSpace(component))
+		}
+	}
+
+	if separator, ok := options["separator"]; ok {
+		signer.Separator = strings.ReplaceAll(separator, `\n`, "\n")
+	}
+
+	if _, err = signer.hash(); err != nil {
+		return nil, err
+	}
+
+	if signer.Encoding != "hex" && signer.Encoding != "base64" {
+		return nil, fmt.Errorf("%w: encoding should be hex or base64, got %s", ErrInvalidOption, signer.Encoding)
+	}
+
+	return signer, nil
+}
+
+// Sign computes request signature and sets it in signer header.
+func (signer *HMACSigner) Sign(req *http.Request) error {
+	if signer.usesComponent("timestamp") && req.Header.Get(signer.TimestampHeader) == "" {
+		req.Header.Set(signer.TimestampHeader, strconv.FormatInt(signer.now().Unix(), 10))
+	}
+
+	signature, err := signer.signature(req)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set(signer.Header, signer.Prefix+signature)
+
+	return nil
+}
+
+// Verify ensures request signer header matches expected signature.
+func (signer *HMACSigner) Verify(req *http.Request) error {
+	actual := strings.TrimPrefix(req.Header.Get(signer.Header), signer.Prefix)
+	if actual == "" {
+		return fmt.Errorf("%w: missing %s header", ErrInvalidSignature, signer.Header)
+	}
+
+	expected, err := signer.signature(req)
+	if err != nil {
+		return err
+	}
+
+	if !hmac.Equal([]byte(actual), []byte(expected)) {
+		return fmt.Errorf("%w: %s does not match expected signature", ErrInvalidSignature, signer.Header)
+	}
+
+	return nil
+}
+
+// CanonicalString builds the string signed for provided request.
+func (signer *HMACSigner) CanonicalString(req *http.Request) (string, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return "", err
+	}
+
+	parts := make([]string, 0, len(signer.Components))
+
+	for _, component := range signer.Components {
+		switch {
+		case component == "method":
+			parts = append(parts, req.Method)
+		case component == "path":
+			parts = append(parts, req.URL.EscapedPath())
+		case component == "query":
+			parts = append(parts, req.URL.RawQuery)
+		case component == "body":
+			parts = append(parts, string(body))
+		case component == "body-hash":
+			parts = append(parts, hashHex(body))
+		case component == "timestamp":
+			parts = append(parts, req.Header.Get(signer.TimestampHeader))
+		case strings.HasPrefix(component, "header:"):
+			parts = append(parts, req.Header.Get(strings.TrimPrefix(component, "header:")))
+		default:
+			return "", fmt.Errorf("%w: unknown canonical component %s", ErrInvalidOption, component)
+		}
+	}
+
+	return strings.Join(parts, signer.Separator), nil
+}
+
+func (signer *HMACSigner) signature(req *http.Request) (string, error) {
+	canonical, err := signer.CanonicalString(req)
+	if err != nil {
+		return "", err
+	}
+
+	hashFn, err := signer.hash()
+	if err != nil {
+		return "", err
+	}
+
+	mac := hmac.New(hashFn, signer.Secret)
+	mac.Write([]byte(canonical)) // nolint: errcheck
+
+	if signer.Encoding == "base64" {
+		return base64.StdEncoding.EncodeToString(mac.Sum(nil)), nil
+	}
+
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+func (signer *HMACSigner) hash() (func() hash.Hash, error) {
+	switch strings.ToLower(signer.Algorithm) {
+	case "sha1":
+		return sha1.New, nil
+	case "sha256":
+		return sha256.New, nil
+	case "sha512":
+		return sha512.New, nil
+	default:
+		return nil, fmt.Errorf("%w: unsupported algorithm %s", ErrInvalidOption, signer.Algorithm)
+	}
+}
+
+func (signer *HMACSigner) usesComponent(name string) bool {
+	for _, component := range signer.Components {
+		if component == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NewAWSV4Signer initializes an AWSV4Signer from options.
+// Credentials are read from `access_key`, `secret_key` and `session_token`
+// options or from environment variables named by `*_env` options.
+func NewAWSV4Signer(options map[string]string) (*AWSV4Signer, error) {
+	var err error
+
+	signer := &AWSV4Signer{
+		Region:  options["region"],
+		Service: options["service"],
+		now:     time.Now,
+	}
+
+	if signer.AccessKey, err = optionOrEnv(options, "access_key"); err != nil {
+		return nil, err
+	}
+
+	if signer.SecretKey, err = optionOrEnv(options, "secret_key"); err != nil {
+		return nil, err
+	}
+
+	if signer.SessionToken, err = optionOrEnv(options, "session_token"); err != nil && !errors.Is(err, ErrMissingOption) {
+		return nil, err
+	}
+
+	if signer.Region == "" || signer.Service == "" {
+		return nil, fmt.Errorf("%w: region and service are required", ErrMissingOption)
+	}
+
+	return signer, nil
+}
+
+// Sign sets AWS Signature V4 Authorization header on request.
+func (signer *AWSV4Signer) Sign(req *http.Request) error {
+	now := signer.now().UTC()
+
+	req.Header.Set("X-Amz-Date", now.Format(awsDateFormat))
+
+	if signer.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", signer.SessionToken)
+	}
+
+	if signer.Service == "s3" {
+		body, err := readRequestBody(req)
+		if err != nil {
+			return err
+		}
+
+		req.Header.Set("X-Amz-Content-Sha256", hashHex(body))
+	}
+
+	authorization, err := signer.authorization(req, now)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", authorization)
+
+	return nil
+}
+
+// Verify ensures request Authorization header matches expected AWS Signature V4.
+func (signer *AWSV4Signer) Verify(req *http.Request) error {
+	date, err := time.Parse(awsDateFormat, req.Header.Get("X-Amz-Date"))
+	if err != nil {
+		return fmt.Errorf("%w: invalid X-Amz-Date header", ErrInvalidSignature)
+	}
+
+	signedHeaders := ""
+
+	for _, part := range strings.Split(strings.TrimPrefix(req.Header.Get("Authorization"), awsAlgorithm+" "), ",") {
+		part = strings.TrimSpace(part)
+		if strings.HasPrefix(part, "SignedHeaders=") {
+			signedHeaders = strings.TrimPrefix(part, "SignedHeaders=")
+		}
+	}
+
+	if signedHeaders == "" {
+		return fmt.Errorf("%w: missing signed headers", ErrInvalidSignature)
+	}
+
+	expected, err := signer.authorizationFor(req, date, strings.Split(signedHeaders, ";"))
+	if err != nil {
+		return err
+	}
+
+	if !hmac.Equal([]byte(req.Header.Get("Authorization")), []byte(expected)) {
+		return fmt.Errorf("%w: Authorization does not match expected signature", ErrInvalidSignature)
+	}
+
+	return nil
+}
+
+func (signer *AWSV4Signer) authorization(req *http.Request, now time.Time) (string, error) {
+	headers := []string{"host", "x-amz-date"}
+
+	if req.Header.Get("X-Amz-Content-Sha256") != "" {
+		headers = append(headers, "x-amz-content-sha256")
+	}
+
+	if req.Header.Get("X-Amz-Security-Token") != "" {
+		headers = append(headers, "x-amz-security-token")
+	}
+
+	if req.Header.Get("Content-Type") != "" {
+		headers = append(headers, "content-type")
+	}
+
+	return signer.authorizationFor(req, now, headers)
+}
+
+func (signer *AWSV4Signer) authorizationFor(req *http.Request, now time.Time, headers []string) (string, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return "", err
+	}
+
+	sort.Strings(headers)
+
+	var canonicalHeaders strings.Builder
+
+	for _, name := range headers {
+		value := req.Header.Get(name)
+		if name == "host" {
+			value = requestHost(req)
+		}
+
+		canonicalHeaders.WriteString(name + ":" + strings.Join(strings.Fields(value), " ") + "\n")
+	}
+
+	payloadHash := req.Header.Get("X-Amz-Content-Sha256")
+	if payloadHash == "" {
+		payloadHash = hashHex(body)
+	}
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		awsCanonicalQuery(req),
+		canonicalHeaders.String(),
+		strings.Join(headers, ";"),
+		payloadHash,
+	}, "\n")
+
+	scope := strings.Join([]string{now.Format(awsDayFormat), signer.Region, signer.Service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{awsAlgorithm, now.Format(awsDateFormat), scope, hashHex([]byte(canonicalRequest))}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+signer.SecretKey), now.Format(awsDayFormat))
+	key = hmacSHA256(key, signer.Region)
+	key = hmacSHA256(key, signer.Service)
+	key = hmacSHA256(key, "aws4_request")
+
+	return fmt.Sprintf(
+		"%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		awsAlgorithm, signer.AccessKey, scope, strings.Join(headers, ";"),
+		hex.EncodeToString(hmacSHA256(key, stringToSign)),
+	), nil
+}
+
+func awsCanonicalQuery(req *http.Request) string {
+	query := req.URL.Query()
+	keys := make([]string, 0, len(query))
+
+	for key := range query {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+
+	for _, key := range keys {
+		values := query[key]
+		sort.Strings(values)
+
+		for _, value := range values {
+			parts = append(parts, awsEscape(key)+"="+awsEscape(value))
+		}
+	}
+
+	return strings.Join(parts, "&")
+}
+
+func awsEscape(s string) string {
+	var b strings.Builder
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') ||
+			c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+
+	return b.String()
+}
+
+func requestHost(req *http.Request) string {
+	if req.Host != "" {
+		return req.Host
+	}
+
+	return req.URL.Host
+}
+
+// readRequestBody reads request body and restores it so request can still be emitted.
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	if req.GetBody != nil {
+		reader, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+
+		defer reader.Close() // nolint: errcheck
+
+		return ioutil.ReadAll(reader)
+	}
+
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(body)), nil
+	}
+
+	return body, nil
+}
+
+func hashHex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data)) // nolint: errcheck
+
+	return mac.Sum(nil)
+}
+
+func optionOrEnv(options map[string]string, name string) (string, error) {
+	if value, ok := options[name]; ok && value != "" {
+		return value, nil
+	}
+
+	// a named variable is expected to be set, even for optional values.
+	if env, ok := options[name+"_env"]; ok {
+		if value := os.Getenv(env); value != "" {
+			return value, nil
+		}
+
+		return "", fmt.Errorf("%w: environment variable %s is not set", ErrInvalidOption, env)
+	}
+
+	return "", fmt.Errorf("%w: %s", ErrMissingOption, name)
+}
+
+func withDefault(value, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+
+	return value
+}
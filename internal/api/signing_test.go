@@ -0,0 +1,174 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/elmagician/kactus/internal/api"
+	. "github.com/elmagician/kactus/internal/test"
+)
+
+func TestUnit_HMACSigner(t *testing.T) {
+	Convey("When I sign a request using HMAC", t, func() {
+		signer, err := api.NewHMACSigner(map[string]string{
+			"secret":     "s3cr3t",
+			"components": "method,path,body",
+			"separator":  "|",
+			"prefix":     "sha256=",
+		})
+		So(err, ShouldBeNil)
+
+		req, err := http.NewRequest(http.MethodPost, "http://localhost/hooks?foo=bar", strings.NewReader(`{"id":1}`))
+		So(err, ShouldBeNil)
+
+		Convey("canonical string should follow components", func() {
+			canonical, err := signer.CanonicalString(req)
+
+			So(err, ShouldBeNil)
+			So(canonical, ShouldEqual, `POST|/hooks|{"id":1}`)
+		})
+
+		Convey("signature should be verified by the same configuration", func() {
+			So(signer.Sign(req), ShouldBeNil)
+			So(req.Header.Get("X-Signature"), ShouldStartWith, "sha256=")
+			So(signer.Verify(req), ShouldBeNil)
+		})
+
+		Convey("verification should fail if body changed", func() {
+			So(signer.Sign(req), ShouldBeNil)
+
+			tampered, err := http.NewRequest(http.MethodPost, "http://localhost/hooks", strings.NewReader(`{"id":2}`))
+			So(err, ShouldBeNil)
+
+			tampered.Header = req.Header
+
+			So(signer.Verify(tampered), ShouldBeLikeError, api.ErrInvalidSignature)
+		})
+	})
+
+	Convey("When I initialize an HMAC signer", t, func() {
+		Convey("should fail without secret", func() {
+			_, err := api.NewHMACSigner(map[string]string{})
+
+			So(err, ShouldBeLikeError, api.ErrMissingOption)
+		})
+
+		Convey("should fail on unsupported algorithm", func() {
+			_, err := api.NewHMACSigner(map[string]string{"secret": "s", "algorithm": "md4"})
+
+			So(err, ShouldBeLikeError, api.ErrInvalidOption)
+		})
+
+		Convey("should read secret from environment", func() {
+			t.Setenv("KACTUS_TEST_SECRET", "fromEnv")
+
+			signer, err := api.NewHMACSigner(map[string]string{"secret_env": "KACTUS_TEST_SECRET"})
+
+			So(err, ShouldBeNil)
+			So(string(signer.Secret), ShouldEqual, "fromEnv")
+		})
+	})
+}
+
+func TestUnit_AWSV4Signer(t *testing.T) {
+	Convey("When I sign a request using AWS Signature V4", t, func() {
+		signer, err := api.NewAWSV4Signer(map[string]string{
+			"access_key": "AKIDEXAMPLE",
+			"secret_key": "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+			"region":     "us-east-1",
+			"service":    "service",
+		})
+		So(err, ShouldBeNil)
+
+		api.SetSignerClock(signer, time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+
+		req, err := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+		So(err, ShouldBeNil)
+
+		Convey("should match AWS reference signature", func() {
+			So(signer.Sign(req), ShouldBeNil)
+			So(
+				req.Header.Get("Authorization"), ShouldEqual,
+				"AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, "+
+					"SignedHeaders=host;x-amz-date, "+
+					"Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31",
+			)
+		})
+
+		Convey("should be verified by the same configuration", func() {
+			So(signer.Sign(req), ShouldBeNil)
+			So(signer.Verify(req), ShouldBeNil)
+
+			req.Header.Set("Authorization", strings.Replace(req.Header.Get("Authorization"), "Signature=5", "Signature=6", 1))
+			So(signer.Verify(req), ShouldBeLikeError, api.ErrInvalidSignature)
+		})
+	})
+
+	Convey("When I initialize an AWS Signature V4 signer", t, func() {
+		options := map[string]string{
+			"access_key": "AKIDEXAMPLE",
+			"secret_key": "secret",
+			"region":     "us-east-1",
+			"service":    "service",
+		}
+
+		Convey("should read session token from environment", func() {
+			t.Setenv("KACTUS_TEST_TOKEN", "token")
+			options["session_token_env"] = "KACTUS_TEST_TOKEN"
+
+			signer, err := api.NewAWSV4Signer(options)
+			So(err, ShouldBeNil)
+			So(signer.SessionToken, ShouldEqual, "token")
+		})
+
+		Convey("should fail when session token variable is not set", func() {
+			options["session_token_env"] = "KACTUS_TEST_UNSET_TOKEN"
+
+			_, err := api.NewAWSV4Signer(options)
+			So(err, ShouldBeLikeError, api.ErrInvalidOption)
+		})
+
+		Convey("should sign without session token when none is configured", func() {
+			signer, err := api.NewAWSV4Signer(options)
+			So(err, ShouldBeNil)
+			So(signer.SessionToken, ShouldBeEmpty)
+		})
+	})
+}
+
+func TestUnit_Client_EmitRequest_Signing(t *testing.T) {
+	Convey("Given a client signing requests", t, func() {
+		var received http.Header
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received = r.Header.Clone()
+		}))
+		defer server.Close()
+
+		cli, err := api.NewClient(&http.Client{})
+		So(err, ShouldBeNil)
+
+		signer, err := api.NewHMACSigner(map[string]string{"secret": "s3cr3t"})
+		So(err, ShouldBeNil)
+
+		cli.SetSigner(signer)
+
+		req := api.PrepareRequest(false).SetMethod(http.MethodGet).SetEndpoint(server.URL).AddHeader("X-Custom", "1")
+		So(cli.EmitRequest(req), ShouldBeNil)
+
+		Convey("should sign emitted request only", func() {
+			So(received.Get("X-Signature"), ShouldNotBeEmpty)
+			So(*req.Headers, ShouldResemble, http.Header{"X-Custom": {"1"}})
+
+			cli.SetSigner(nil)
+			So(cli.EmitRequest(req), ShouldBeNil)
+			So(received.Get("X-Signature"), ShouldBeEmpty)
+			So(received.Get("X-Timestamp"), ShouldBeEmpty)
+		})
+	})
+}
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// unixScheme prefixes endpoints served on a Unix domain socket:
+// `unix:///var/run/app.sock:/v1/status`.
+const unixScheme = "unix://"
+
+// unixHost is the host used for requests emitted on Unix domain sockets.
+const unixHost = "localhost"
+
+// ErrInvalidSocket is thrown when an Unix socket endpoint has no socket path.
+var ErrInvalidSocket = errors.New("invalid unix socket endpoint")
+
+type unixSocketKey struct{}
+
+// unixRoute routes requests targeting host to socket. Redirections
+// to other hosts are emitted on the network.
+type unixRoute struct {
+	socket string
+	host   string
+}
+
+// SetUnixSocket dials socket for every request emitted by client, whatever
+// endpoint host. Providing an empty path disables it.
+// Socket is reset with client.
+func (cli *Client) SetUnixSocket(path string) {
+	cli.socket = path
+}
+
+// SplitUnixEndpoint splits an `unix://<socket>:<path>` endpoint in socket
+// path and an HTTP endpoint targeting path. Other endpoints are returned
+// unchanged with an empty socket.
+func SplitUnixEndpoint(endpoint string) (socket, target string, err error) {
+	if !strings.HasPrefix(endpoint, unixScheme) {
+		return "", endpoint, nil
+	}
+
+	socket, path, _ := strings.Cut(strings.TrimPrefix(endpoint, unixScheme), ":")
+	if socket == "" {
+		return "", endpoint, fmt.Errorf("%w: %s", ErrInvalidSocket, endpoint)
+	}
+
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	return socket, "http://" + unixHost + path, nil
+}
+
+// generateRequest generates request from preparation, routing it to an Unix
+// socket when endpoint or client requires it.
+func (cli *Client) generateRequest(req RequestPreparation) (*http.Request, error) {
+	socket, endpoint, err := SplitUnixEndpoint(req.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if socket == "" {
+		socket = cli.socket
+	}
+
+	req = req.SetEndpoint(endpoint)
+
+	generated, err := req.GenerateRequest(cli.client.Jar)
+	if err != nil || socket == "" {
+		return generated, err
+	}
+
+	route := unixRoute{socket: socket, host: generated.URL.Host}
+
+	return generated.WithContext(context.WithValue(generated.Context(), unixSocketKey{}, route)), nil
+}
+
+// unixEndpoint rebuilds an `unix://<socket>:<path>` endpoint for resolved
+// when last request was emitted on an endpoint socket and resolved targets
+// the same host. Client socket needs no rewriting as it applies to any host.
+func (cli *Client) unixEndpoint(resolved *url.URL) (string, bool) {
+	route, ok := cli.request.Context().Value(unixSocketKey{}).(unixRoute)
+	if !ok || route.socket == cli.socket || resolved.Scheme != "http" || resolved.Host != route.host {
+		return "", false
+	}
+
+	return unixScheme + route.socket + ":" + resolved.RequestURI(), true
+}
+
+// unixTransport returns a transport dialing socket.
+func unixTransport(socket string) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+		var dialer net.Dialer
+		return dialer.DialContext(ctx, "unix", socket)
+	}
+
+	return transport
+}
@@ -0,0 +1,182 @@
+package api_test
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/elmagician/kactus/internal/api"
+	. "github.com/elmagician/kactus/internal/test"
+)
+
+func TestUnit_Client_UnixSocket(t *testing.T) {
+	Convey("Given a server listening on an unix socket", t, func() {
+		dir, err := os.MkdirTemp("", "kactus")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		socket := filepath.Join(dir, "app.sock")
+		listener, err := net.Listen("unix", socket)
+		So(err, ShouldBeNil)
+
+		remote := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Remote", r.URL.Path)
+		}))
+		defer remote.Close()
+
+		server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if to := r.URL.Query().Get("redirect"); to != "" {
+				http.Redirect(w, r, to, http.StatusFound)
+				return
+			}
+
+			w.Header().Set("X-Path", r.URL.RequestURI())
+			w.Header().Set("X-Host", r.Host)
+			w.WriteHeader(http.StatusAccepted)
+		})}
+
+		go server.Serve(listener) // nolint: errcheck
+		defer server.Close()
+
+		cli, err := api.NewClient(&http.Client{})
+		So(err, ShouldBeNil)
+
+		Convey("should dial socket from endpoint", func() {
+			req := api.PrepareRequest(false).
+				SetMethod(http.MethodGet).
+				SetEndpoint("unix://"+socket+":/v1/status").
+				AddArgument("verbose", "true")
+
+			So(cli.EmitRequest(req), ShouldBeNil)
+			So(cli.Response.Status, ShouldEqual, http.StatusAccepted)
+			So(cli.Response.Headers.Get("X-Path"), ShouldEqual, "/v1/status?verbose=true")
+			So(cli.Response.Headers.Get("X-Host"), ShouldEqual, "localhost")
+		})
+
+		Convey("should dial client socket", func() {
+			cli.SetUnixSocket(socket)
+
+			req := api.PrepareRequest(false).SetMethod(http.MethodGet).SetEndpoint("http://daemon/health")
+			So(cli.EmitRequest(req), ShouldBeNil)
+			So(cli.Response.Headers.Get("X-Path"), ShouldEqual, "/health")
+			So(cli.Response.Headers.Get("X-Host"), ShouldEqual, "daemon")
+
+			report, err := cli.Burst(req, 4, 2)
+			So(err, ShouldBeNil)
+			So(report.StatusCount(http.StatusAccepted), ShouldEqual, 4)
+		})
+
+		Convey("should follow redirects on socket host only", func() {
+			req := api.PrepareRequest(false).
+				SetMethod(http.MethodGet).
+				SetEndpoint("unix://"+socket+":/").
+				AddArgument("redirect", "/v1/status")
+
+			So(cli.EmitRequest(req), ShouldBeNil)
+			So(cli.Response.Headers.Get("X-Path"), ShouldEqual, "/v1/status")
+
+			req = req.AddArgument("redirect", remote.URL+"/landing")
+
+			So(cli.EmitRequest(req), ShouldBeNil)
+			So(cli.Response.Status, ShouldEqual, http.StatusOK)
+			So(cli.Response.Headers.Get("X-Remote"), ShouldEqual, "/landing")
+		})
+
+		Convey("should forget client socket on reset", func() {
+			cli.SetUnixSocket(socket)
+
+			req := api.PrepareRequest(false).SetMethod(http.MethodGet).SetEndpoint("http://daemon.invalid/health")
+			So(cli.EmitRequest(req), ShouldBeNil)
+
+			cli.Reset()
+			So(cli.EmitRequest(req), ShouldNotBeNil)
+		})
+
+		Convey("should reject endpoints without socket", func() {
+			req := api.PrepareRequest(false).SetMethod(http.MethodGet).SetEndpoint("unix://:/v1/status")
+			So(cli.EmitRequest(req), ShouldBeLikeError, api.ErrInvalidSocket)
+		})
+	})
+}
+
+func TestUnit_SplitUnixEndpoint(t *testing.T) {
+	Convey("Given endpoints", t, func() {
+		socket, target, err := api.SplitUnixEndpoint("unix:///var/run/app.sock:/v1/status")
+		So(err, ShouldBeNil)
+		So(socket, ShouldEqual, "/var/run/app.sock")
+		So(target, ShouldEqual, "http://localhost/v1/status")
+
+		socket, target, err = api.SplitUnixEndpoint("unix:///var/run/app.sock")
+		So(err, ShouldBeNil)
+		So(socket, ShouldEqual, "/var/run/app.sock")
+		So(target, ShouldEqual, "http://localhost/")
+
+		socket, target, err = api.SplitUnixEndpoint("http://host/path")
+		So(err, ShouldBeNil)
+		So(socket, ShouldBeEmpty)
+		So(target, ShouldEqual, "http://host/path")
+	})
+}
+
+func TestUnit_Client_UnixSocket_Paginate(t *testing.T) {
+	Convey("Given a paginated API listening on an unix socket", t, func() {
+		dir, err := os.MkdirTemp("", "kactus")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		socket := filepath.Join(dir, "app.sock")
+		listener, err := net.Listen("unix", socket)
+		So(err, ShouldBeNil)
+
+		server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			if r.URL.Query().Get("page") == "2" {
+				_, _ = w.Write([]byte(`{"data": [3]}`))
+				return
+			}
+			w.Header().Set("Link", `</items?page=2>; rel="next"`)
+			_, _ = w.Write([]byte(`{"data": [1, 2]}`))
+		})}
+
+		go server.Serve(listener) // nolint: errcheck
+		defer server.Close()
+
+		cli, err := api.NewClient(&http.Client{})
+		So(err, ShouldBeNil)
+
+		req := api.PrepareRequest(false).SetMethod(http.MethodGet).SetEndpoint("unix://" + socket + ":/items")
+
+		Convey("should resolve links on endpoint socket", func() {
+			So(cli.EmitRequest(req), ShouldBeNil)
+
+			link, ok := api.FindLink(cli.Response.Headers, "next")
+			So(ok, ShouldBeTrue)
+
+			next, err := cli.ResolveURL(link)
+			So(err, ShouldBeNil)
+			So(next, ShouldEqual, "unix://"+socket+":/items?page=2")
+		})
+
+		Convey("should follow pages on endpoint socket", func() {
+			pagination, err := api.NewPagination(map[string]string{"items": "data"})
+			So(err, ShouldBeNil)
+
+			items, err := cli.Paginate(req, pagination)
+			So(err, ShouldBeNil)
+			So(items, ShouldResemble, []interface{}{1.0, 2.0, 3.0})
+		})
+
+		Convey("should keep absolute links on their host", func() {
+			So(cli.EmitRequest(req), ShouldBeNil)
+
+			target, err := cli.ResolveURL("http://example.com/items")
+			So(err, ShouldBeNil)
+			So(target, ShouldEqual, "http://example.com/items")
+		})
+	})
+}
@@ -0,0 +1,64 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/elmagician/kactus/internal/api"
+	. "github.com/elmagician/kactus/internal/test"
+)
+
+func TestUnit_Client_Timeout(t *testing.T) {
+	Convey("Given a slow endpoint", t, func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-time.After(200 * time.Millisecond):
+			case <-r.Context().Done():
+			}
+
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		cli, err := api.NewClient(&http.Client{})
+		So(err, ShouldBeNil)
+
+		req := api.PrepareRequest(false).SetEndpoint(server.URL)
+
+		Convey("should time out using client default", func() {
+			cli.SetDefaultTimeout(20 * time.Millisecond)
+
+			err := cli.EmitRequest(req)
+
+			So(err, ShouldBeLikeError, api.ErrTimeout)
+			So(errors.Is(err, context.DeadlineExceeded), ShouldBeTrue)
+
+			Convey("and keep default on reset", func() {
+				cli.Reset()
+
+				So(cli.EmitRequest(req), ShouldBeLikeError, api.ErrTimeout)
+			})
+		})
+
+		Convey("should prefer request timeout", func() {
+			cli.SetDefaultTimeout(20 * time.Millisecond)
+
+			So(cli.EmitRequest(req.SetTimeout(time.Second)), ShouldBeNil)
+			So(cli.Response.Status, ShouldEqual, http.StatusOK)
+		})
+
+		Convey("should time out burst requests", func() {
+			report, err := cli.Burst(req.SetTimeout(20*time.Millisecond), 2, 2)
+
+			So(err, ShouldBeNil)
+			So(report.Errors(), ShouldHaveLength, 2)
+			So(report.Errors()[0], ShouldBeLikeError, api.ErrTimeout)
+		})
+	})
+}
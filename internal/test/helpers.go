@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/cucumber/godog"
+	messages "github.com/cucumber/messages/go/v21"
 	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/mock"
 )
@@ -103,3 +105,21 @@ func ResetMock(m *mock.Mock) {
 	m.ExpectedCalls = []*mock.Call{}
 	m.Calls = []mock.Call{}
 }
+
+// NewTable builds a godog table from provided rows.
+// First row is expected to be the table header.
+func NewTable(rows ...[]string) *godog.Table {
+	table := &godog.Table{}
+
+	for _, row := range rows {
+		tableRow := &messages.PickleTableRow{}
+
+		for _, cell := range row {
+			tableRow.Cells = append(tableRow.Cells, &messages.PickleTableCell{Value: cell})
+		}
+
+		table.Rows = append(table.Rows, tableRow)
+	}
+
+	return table
+}
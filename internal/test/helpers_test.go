@@ -173,3 +173,16 @@ var (
 	testMock  = &Mock{}
 	testMock2 = &Mock2{}
 )
+
+func TestUnit_NewTable(t *testing.T) {
+	Convey("When I build a godog table", t, func() {
+		table := test.NewTable(
+			[]string{"field", "value"},
+			[]string{"foo", "bar"},
+		)
+
+		So(table.Rows, ShouldHaveLength, 2)
+		So(table.Rows[0].Cells[0].Value, ShouldEqual, "field")
+		So(table.Rows[1].Cells[1].Value, ShouldEqual, "bar")
+	})
+}
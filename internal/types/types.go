@@ -61,6 +61,10 @@ func ToInterface(value string) (interface{}, error) {
 		log.Debug("No conversion required", zap.String("value", val))
 		return val, nil
 	case "array":
+		if strings.Trim(val, "[]") == "" {
+			return []interface{}{}, nil
+		}
+
 		if val[0] == '[' {
 			val = val[1:]
 		}
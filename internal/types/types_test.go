@@ -72,6 +72,16 @@ func TestUnit_ToInterface(t *testing.T) {
 			So(matched, ShouldBeEquivalent, []interface{}{int64(1), 2.45, "test"})
 		})
 
+		Convey("should be able to parse an empty array", func() {
+			val, err := types.ToInterface("[]((array))")
+			So(err, ShouldBeNil)
+			So(val, ShouldResemble, []interface{}{})
+
+			val, err = types.ToInterface("((array))")
+			So(err, ShouldBeNil)
+			So(val, ShouldResemble, []interface{}{})
+		})
+
 		Convey("should have error when type asked could not be casted", func() {
 			val, err := types.ToInterface("azetr((int))")
 			So(err, ShouldNotBeNil)